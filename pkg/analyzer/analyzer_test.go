@@ -12,12 +12,37 @@ func TestAnalyzer(t *testing.T) {
 	t.Parallel()
 
 	testCases := []struct {
-		desc string
-		dir  string
+		desc  string
+		dir   string
+		flags map[string]string
 	}{
 		{
 			desc: "common",
 			dir:  "common",
+			flags: map[string]string{
+				"blocking-calls": "common.callInventoryService",
+			},
+		},
+		{
+			desc: "required fields",
+			dir:  "common/requiredfields",
+			flags: map[string]string{
+				"required-fields": "BeginTransactionOptions.LockTimeout,QueryOptions.Options.MaxRuntime," +
+					"QueryOptions.MemoryLimit," +
+					"CollectionDocumentCreateOptions.WithWaitForSync=writes must set WithWaitForSync explicitly," +
+					"CollectionDocumentCreateOptions.WaitForSync,QueryOption.MemoryLimit",
+			},
+		},
+		{
+			desc: "destructive allowed packages",
+			dir:  "common/migrations",
+			flags: map[string]string{
+				"destructive-allowed-packages": "*/admin,*/migrations",
+			},
+		},
+		{
+			desc: "index coverage",
+			dir:  "common/indexadvisor",
 		},
 		{
 			desc: "cgo",
@@ -30,8 +55,13 @@ func TestAnalyzer(t *testing.T) {
 			t.Parallel()
 
 			anlzr := analyzer.NewAnalyzer()
+			for name, value := range test.flags {
+				if err := anlzr.Flags.Set(name, value); err != nil {
+					t.Fatal(err)
+				}
+			}
 
-			analysistest.Run(t, analysistest.TestData(), anlzr, test.dir)
+			analysistest.RunWithSuggestedFixes(t, analysistest.TestData(), anlzr, test.dir)
 		})
 	}
 }
@@ -0,0 +1,414 @@
+package analyzer
+
+import (
+	"slices"
+	"strings"
+)
+
+// aqlTokenKind classifies the tokens of an AQL query.
+type aqlTokenKind int
+
+const (
+	aqlName aqlTokenKind = iota
+	aqlString
+	aqlNumber
+	aqlBindParam
+	aqlPunct
+)
+
+const (
+	aqlKeywordFor     = "FOR"
+	aqlKeywordFilter  = "FILTER"
+	aqlKeywordSearch  = "SEARCH"
+	aqlKeywordSort    = "SORT"
+	aqlKeywordLimit   = "LIMIT"
+	aqlKeywordLet     = "LET"
+	aqlKeywordCollect = "COLLECT"
+	aqlKeywordReturn  = "RETURN"
+	aqlKeywordInsert  = "INSERT"
+	aqlKeywordUpdate  = "UPDATE"
+	aqlKeywordReplace = "REPLACE"
+	aqlKeywordRemove  = "REMOVE"
+	aqlKeywordUpsert  = "UPSERT"
+	aqlKeywordIn      = "IN"
+	aqlKeywordInto    = "INTO"
+	aqlKeywordOptions = "OPTIONS"
+)
+
+// aqlClauseKeywords lists the keywords starting a high-level operation.
+var aqlClauseKeywords = []string{
+	aqlKeywordFor, aqlKeywordFilter, aqlKeywordSearch, aqlKeywordSort, aqlKeywordLimit, aqlKeywordLet,
+	aqlKeywordCollect, "WINDOW", aqlKeywordReturn, aqlKeywordInsert, aqlKeywordUpdate, aqlKeywordReplace,
+	aqlKeywordRemove, aqlKeywordUpsert,
+}
+
+// aqlOperators lists the AQL operators made of two characters.
+var aqlOperators = []string{"==", "!=", "<=", ">=", "&&", "||", "..", "=~", "!~", "::", "?:"}
+
+// aqlToken is a lexical token of an AQL query. Comments and whitespace are
+// dropped.
+type aqlToken struct {
+	kind aqlTokenKind
+	// text is the source text of the token, without the quotes of strings and
+	// quoted names.
+	text string
+	// quoted is set for names written with backticks or forward ticks.
+	quoted bool
+	// offset and end delimit the token, quotes included, in the query.
+	offset, end int
+}
+
+// isPunct reports whether tok is one of the punctuation or operator texts.
+func (tok aqlToken) isPunct(texts ...string) bool {
+	return tok.kind == aqlPunct && slices.Contains(texts, tok.text)
+}
+
+// isKeyword reports whether tok is the (case-insensitive) keyword.
+func (tok aqlToken) isKeyword(keyword string) bool {
+	return tok.kind == aqlName && !tok.quoted && strings.EqualFold(tok.text, keyword)
+}
+
+// lexAQL splits query into tokens. It is lenient: malformed input yields
+// best-effort tokens instead of errors.
+func lexAQL(query string) []aqlToken {
+	var tokens []aqlToken
+
+	for pos := 0; pos < len(query); {
+		char := query[pos]
+
+		switch {
+		case char == ' ' || char == '\t' || char == '\n' || char == '\r':
+			pos++
+		case strings.HasPrefix(query[pos:], "//"):
+			pos = skipUntil(query, pos, "\n")
+		case strings.HasPrefix(query[pos:], "/*"):
+			pos = skipUntil(query, pos+2, "*/") //nolint:mnd // opening of the comment
+		case char == '\'' || char == '"':
+			end := scanQuoted(query, pos, string(char))
+			tokens = append(tokens, aqlToken{
+				kind: aqlString, text: unescapeAQL(query[pos+1 : max(pos+1, end-1)]), offset: pos, end: end,
+			})
+			pos = end
+		case char == '`' || strings.HasPrefix(query[pos:], "´"):
+			quote := query[pos : pos+1]
+			if char != '`' {
+				quote = "´"
+			}
+
+			end := scanQuoted(query, pos, quote)
+			tokens = append(tokens, aqlToken{
+				kind: aqlName, text: query[pos+len(quote) : max(pos+len(quote), end-len(quote))], quoted: true,
+				offset: pos, end: end,
+			})
+			pos = end
+		case char == '@':
+			end := pos + 1
+			if end < len(query) && query[end] == '@' {
+				end++
+			}
+
+			end = scanWhile(query, end, isAQLNameChar)
+			tokens = append(tokens, aqlToken{kind: aqlBindParam, text: query[pos:end], offset: pos, end: end})
+			pos = end
+		case isAQLNameStart(char):
+			end := scanWhile(query, pos, isAQLNameChar)
+			tokens = append(tokens, aqlToken{kind: aqlName, text: query[pos:end], offset: pos, end: end})
+			pos = end
+		case isDigit(char):
+			end := scanNumber(query, pos)
+			tokens = append(tokens, aqlToken{kind: aqlNumber, text: query[pos:end], offset: pos, end: end})
+			pos = end
+		default:
+			end := pos + 1
+			if pos+2 <= len(query) && slices.Contains(aqlOperators, query[pos:pos+2]) {
+				end = pos + 2 //nolint:mnd // two-character operator
+			}
+
+			tokens = append(tokens, aqlToken{kind: aqlPunct, text: query[pos:end], offset: pos, end: end})
+			pos = end
+		}
+	}
+
+	return tokens
+}
+
+// skipUntil returns the offset following the first terminator at or after pos,
+// or the length of query.
+func skipUntil(query string, pos int, terminator string) int {
+	index := strings.Index(query[pos:], terminator)
+	if index < 0 {
+		return len(query)
+	}
+
+	return pos + index + len(terminator)
+}
+
+// scanQuoted returns the offset following the closing quote of the quoted
+// token starting at pos, honoring backslash escapes.
+func scanQuoted(query string, pos int, quote string) int {
+	for end := pos + len(quote); end < len(query); end++ {
+		switch {
+		case query[end] == '\\':
+			end++
+		case strings.HasPrefix(query[end:], quote):
+			return end + len(quote)
+		}
+	}
+
+	return len(query)
+}
+
+// scanWhile returns the offset of the first byte at or after pos not matching
+// accept.
+func scanWhile(query string, pos int, accept func(byte) bool) int {
+	for pos < len(query) && accept(query[pos]) {
+		pos++
+	}
+
+	return pos
+}
+
+// scanNumber returns the offset following the number starting at pos. A dot is
+// part of the number only when followed by a digit, so that ranges such as
+// 1..10 split.
+func scanNumber(query string, pos int) int {
+	end := scanWhile(query, pos, isDigit)
+	if end+1 < len(query) && query[end] == '.' && isDigit(query[end+1]) {
+		end = scanWhile(query, end+1, isDigit)
+	}
+
+	if end < len(query) && (query[end] == 'e' || query[end] == 'E') {
+		end = scanWhile(query, end+1, func(char byte) bool { return isDigit(char) || char == '-' || char == '+' })
+	}
+
+	return end
+}
+
+// unescapeAQL removes the backslash escapes of the body of an AQL string.
+func unescapeAQL(body string) string {
+	if !strings.Contains(body, `\`) {
+		return body
+	}
+
+	var builder strings.Builder
+
+	for index := 0; index < len(body); index++ {
+		if body[index] == '\\' && index+1 < len(body) {
+			index++
+		}
+
+		builder.WriteByte(body[index])
+	}
+
+	return builder.String()
+}
+
+// isAQLNameStart reports whether char can start an AQL name.
+func isAQLNameStart(char byte) bool {
+	return isASCIILetter(rune(char)) || char == '_' || char == '$'
+}
+
+// isAQLNameChar reports whether char can continue an AQL name.
+func isAQLNameChar(char byte) bool {
+	return isAQLNameStart(char) || isDigit(char)
+}
+
+// isDigit reports whether char is an ASCII digit.
+func isDigit(char byte) bool {
+	return char >= '0' && char <= '9'
+}
+
+// aqlClause is a high-level operation of a query: the keyword and the tokens
+// up to the next operation at the same nesting depth (subqueries included).
+type aqlClause struct {
+	keyword string
+	depth   int
+	tokens  []aqlToken
+}
+
+// aqlLoop is a FOR operation along with the operations following it in its
+// scope.
+type aqlLoop struct {
+	variable string
+	// collection is the name of the collection iterated, when the loop
+	// iterates a collection by name.
+	collection string
+	depth      int
+	forClause  *aqlClause
+	body       []*aqlClause
+}
+
+// aqlQuery is the clause structure of a query.
+type aqlQuery struct {
+	tokens  []aqlToken
+	clauses []*aqlClause
+	loops   []*aqlLoop
+}
+
+// aqlAttribute is an attribute access such as u.address.city.
+type aqlAttribute struct {
+	variable string
+	path     []string
+}
+
+// parseAQL splits query into clauses and loops. Like lexAQL it never fails:
+// unexpected shapes leave loops without a collection or clauses unattached.
+func parseAQL(query string) *aqlQuery {
+	parsed := &aqlQuery{tokens: lexAQL(query)}
+
+	var (
+		open   []*aqlLoop
+		active []*aqlClause
+		depth  int
+	)
+
+	for index, tok := range parsed.tokens {
+		switch {
+		case tok.isPunct("(", "[", "{"):
+			depth++
+		case tok.isPunct(")", "]", "}"):
+			depth--
+			open = slices.DeleteFunc(open, func(loop *aqlLoop) bool { return loop.depth > depth })
+			active = slices.DeleteFunc(active, func(clause *aqlClause) bool { return clause.depth > depth })
+		case startsClause(parsed.tokens, index):
+			clause := &aqlClause{keyword: strings.ToUpper(tok.text), depth: depth}
+			parsed.clauses = append(parsed.clauses, clause)
+			active = slices.DeleteFunc(active, func(other *aqlClause) bool { return other.depth >= depth })
+			active = append(active, clause)
+
+			if clause.keyword == aqlKeywordFor {
+				loop := &aqlLoop{depth: depth, forClause: clause}
+				open = append(open, loop)
+				parsed.loops = append(parsed.loops, loop)
+
+				break
+			}
+
+			for _, loop := range open {
+				if loop.depth == depth {
+					loop.body = append(loop.body, clause)
+				}
+			}
+		}
+
+		for _, clause := range active {
+			clause.tokens = append(clause.tokens, tok)
+		}
+	}
+
+	variables := parsed.variables()
+
+	for _, loop := range parsed.loops {
+		tokens := loop.forClause.tokens
+		if len(tokens) > 1 && tokens[1].kind == aqlName {
+			loop.variable = tokens[1].text
+		}
+
+		const collectionLoopLength = 4 // FOR variable IN collection
+
+		if len(tokens) >= collectionLoopLength && tokens[2].isKeyword(aqlKeywordIn) &&
+			tokens[3].kind == aqlName && !slices.Contains(variables, tokens[3].text) &&
+			(len(tokens) == collectionLoopLength || tokens[4].isKeyword(aqlKeywordOptions)) {
+			loop.collection = tokens[3].text
+		}
+	}
+
+	return parsed
+}
+
+// startsClause reports whether the token at index is a keyword starting an
+// operation, rather than an attribute name (u.filter) or an object key
+// ({sort: 1}).
+func startsClause(tokens []aqlToken, index int) bool {
+	tok := tokens[index]
+	if tok.kind != aqlName || tok.quoted || !slices.Contains(aqlClauseKeywords, strings.ToUpper(tok.text)) {
+		return false
+	}
+
+	if index > 0 && tokens[index-1].isPunct(".") {
+		return false
+	}
+
+	return index+1 >= len(tokens) || !tokens[index+1].isPunct(":")
+}
+
+// variables returns the names declared by FOR, LET and COLLECT ... INTO.
+func (query *aqlQuery) variables() []string {
+	var names []string
+
+	for _, clause := range query.clauses {
+		switch clause.keyword {
+		case aqlKeywordFor:
+			for _, tok := range clause.tokens[1:] {
+				if tok.isKeyword(aqlKeywordIn) {
+					break
+				}
+
+				if tok.kind == aqlName {
+					names = append(names, tok.text)
+				}
+			}
+		case aqlKeywordLet:
+			if len(clause.tokens) > 1 {
+				names = append(names, clause.tokens[1].text)
+			}
+		case aqlKeywordCollect:
+			for index, tok := range clause.tokens {
+				if index+1 < len(clause.tokens) && tok.isKeyword(aqlKeywordInto) {
+					names = append(names, clause.tokens[index+1].text)
+				}
+			}
+		}
+	}
+
+	return names
+}
+
+// bodyClauses returns the clauses of the loop body with the keyword.
+func (loop *aqlLoop) bodyClauses(keyword string) []*aqlClause {
+	var clauses []*aqlClause
+
+	for _, clause := range loop.body {
+		if clause.keyword == keyword {
+			clauses = append(clauses, clause)
+		}
+	}
+
+	return clauses
+}
+
+// attributes returns the attribute accesses of clause on variable, such as
+// u.address.city. Accesses followed by an index or an array expansion are
+// skipped.
+func (clause *aqlClause) attributes(variable string) []aqlAttribute {
+	var attributes []aqlAttribute
+
+	tokens := clause.tokens
+
+	for index := 0; index < len(tokens); index++ {
+		tok := tokens[index]
+		if tok.kind != aqlName || tok.text != variable || (index > 0 && tokens[index-1].isPunct(".")) {
+			continue
+		}
+
+		var path []string
+
+		for index+2 < len(tokens) && tokens[index+1].isPunct(".") && tokens[index+2].kind == aqlName {
+			path = append(path, tokens[index+2].text)
+			index += 2
+		}
+
+		if len(path) == 0 || (index+1 < len(tokens) && tokens[index+1].isPunct("[", "(")) {
+			continue
+		}
+
+		attributes = append(attributes, aqlAttribute{variable: variable, path: path})
+	}
+
+	return attributes
+}
+
+// String returns the dotted attribute path, without the variable.
+func (attribute aqlAttribute) String() string {
+	return strings.Join(attribute.path, ".")
+}
@@ -0,0 +1,69 @@
+package analyzer
+
+import (
+	"go/ast"
+	"go/token"
+	"go/types"
+	"slices"
+
+	"golang.org/x/tools/go/analysis"
+)
+
+const (
+	msgBlockingCallInTransaction   = "%s called while streaming transaction %s is open"
+	msgChannelReceiveInTransaction = "channel receive while streaming transaction %s is open"
+)
+
+// handleBlockingInTransactionCall reports blocking or network calls (as
+// configured with the blocking-calls flag) and channel receives made while a
+// streaming transaction is open: between BeginTransaction and the final
+// Commit/Abort in the same function, or inside a WithTransaction callback.
+// Streaming transactions hold locks and expire after an idle timeout.
+// Goroutines and function literals started from the span are not followed.
+func handleBlockingInTransactionCall(call *ast.CallExpr, pass *analysis.Pass, stack []ast.Node, cfg *config) {
+	span := beginTransactionSpan(call, pass, stack)
+	if span == nil {
+		span = withTransactionSpan(call, pass)
+	}
+
+	if span == nil {
+		return
+	}
+
+	ast.Inspect(span.body, func(node ast.Node) bool {
+		if node == nil || !span.overlaps(node) {
+			return false
+		}
+
+		switch typed := node.(type) {
+		case *ast.FuncLit, *ast.GoStmt:
+			return false
+		case *ast.CallExpr:
+			if fn := calledFunc(typed, pass); fn != nil && slices.Contains(cfg.blockingCalls, fn.FullName()) &&
+				span.contains(typed.Pos()) {
+				pass.Reportf(typed.Pos(), msgBlockingCallInTransaction, fn.FullName(), span.name)
+			}
+		case *ast.UnaryExpr:
+			if typed.Op == token.ARROW && span.contains(typed.Pos()) {
+				pass.Reportf(typed.Pos(), msgChannelReceiveInTransaction, span.name)
+			}
+		case *ast.RangeStmt:
+			if isChannel(pass.TypesInfo.TypeOf(typed.X)) && span.contains(typed.Pos()) {
+				pass.Reportf(typed.X.Pos(), msgChannelReceiveInTransaction, span.name)
+			}
+		}
+
+		return true
+	})
+}
+
+// isChannel reports whether t is a channel type.
+func isChannel(t types.Type) bool {
+	if t == nil {
+		return false
+	}
+
+	_, isChan := t.Underlying().(*types.Chan)
+
+	return isChan
+}
@@ -0,0 +1,439 @@
+package analyzer
+
+import (
+	"encoding/json"
+	"fmt"
+	"go/ast"
+	"go/constant"
+	"go/types"
+	"math"
+	"slices"
+
+	"golang.org/x/tools/go/analysis"
+)
+
+const (
+	msgSchemaLevel        = "invalid collection schema level %q; expected none, new, moderate or strict"
+	msgSchemaRule         = "invalid collection schema rule: %s"
+	msgSchemaMalformed    = "invalid collection schema rule: malformed JSON: %v"
+	schemaOptionsTypeName = "CollectionSchemaOptions"
+	schemaRuleFieldName   = "Rule"
+	schemaLevelFieldName  = "Level"
+	methodLoadRule        = "LoadRule"
+	schemaRootPath        = "rule"
+	schemaPathSeparator   = "."
+)
+
+// schemaLevels lists the validation levels accepted by ArangoDB.
+var schemaLevels = []string{"none", "new", "moderate", "strict"}
+
+// schemaTypes lists the JSON Schema draft-04 primitive types.
+var schemaTypes = []string{"array", "boolean", "integer", "null", "number", "object", "string"}
+
+// laterDraftKeywords lists the JSON Schema keywords introduced after draft-04,
+// which ArangoDB silently ignores.
+var laterDraftKeywords = []string{
+	"$id", "$defs", "$comment", "const", "contains", "propertyNames", "if", "then", "else", "examples",
+	"readOnly", "writeOnly", "contentEncoding", "contentMediaType", "dependentRequired", "dependentSchemas",
+	"prefixItems", "unevaluatedItems", "unevaluatedProperties",
+}
+
+// unknownSchemaValue stands for a part of a schema rule that is not known at
+// lint time; it is never reported.
+type unknownSchemaValue struct{}
+
+// handleCollectionSchemaLiteral validates the level and the rule of a
+// CollectionSchemaOptions literal.
+func handleCollectionSchemaLiteral(literal *ast.CompositeLit, pass *analysis.Pass) {
+	if arangoTypeName(pass.TypesInfo.TypeOf(literal)) != schemaOptionsTypeName {
+		return
+	}
+
+	for _, elt := range literal.Elts {
+		keyValue, isKeyValue := elt.(*ast.KeyValueExpr)
+		if !isKeyValue {
+			continue
+		}
+
+		key, isIdent := keyValue.Key.(*ast.Ident)
+		if !isIdent {
+			continue
+		}
+
+		switch key.Name {
+		case schemaLevelFieldName:
+			if level, known := constantString(keyValue.Value, pass); known && !slices.Contains(schemaLevels, level) {
+				pass.Reportf(keyValue.Value.Pos(), msgSchemaLevel, level)
+			}
+		case schemaRuleFieldName:
+			checkSchemaRule(keyValue.Value, pass)
+		}
+	}
+}
+
+// handleLoadRuleCall validates the constant JSON passed to
+// CollectionSchemaOptions.LoadRule.
+func handleLoadRuleCall(call *ast.CallExpr, pass *analysis.Pass) {
+	method := arangoMethod(call, pass)
+	if method == nil || method.Name() != methodLoadRule || methodRecvTypeName(method) != schemaOptionsTypeName ||
+		len(call.Args) != 1 {
+		return
+	}
+
+	checkSchemaRule(call.Args[0], pass)
+}
+
+// checkSchemaRule reports the first problem of the schema rule expr, when it
+// is known at lint time.
+func checkSchemaRule(expr ast.Expr, pass *analysis.Pass) {
+	value, err := schemaValue(expr, pass)
+	if err != nil {
+		pass.Reportf(expr.Pos(), msgSchemaMalformed, err)
+
+		return
+	}
+
+	if _, unknown := value.(unknownSchemaValue); unknown {
+		return
+	}
+
+	if _, isObject := value.(map[string]any); !isObject {
+		pass.Reportf(expr.Pos(), msgSchemaRule, "the rule must be a JSON object")
+
+		return
+	}
+
+	if problem := schemaProblem(value, schemaRootPath); problem != "" {
+		pass.Reportf(expr.Pos(), msgSchemaRule, problem)
+	}
+}
+
+// schemaValue evaluates expr into a JSON value (map[string]any, []any, string,
+// float64, bool or nil), with unknownSchemaValue for parts that are not
+// constant. Map and slice literals, constants, JSON held in constant strings
+// converted to []byte or json.RawMessage, and variables initialized with one of
+// those and never reassigned are evaluated.
+func schemaValue(expr ast.Expr, pass *analysis.Pass) (any, error) {
+	expr = unwrapAddress(expr)
+
+	if tv, found := pass.TypesInfo.Types[expr]; found && tv.Value != nil {
+		return constantSchemaValue(tv.Value), nil
+	}
+
+	switch typed := expr.(type) {
+	case *ast.Ident:
+		if pass.TypesInfo.Types[typed].IsNil() {
+			return nil, nil
+		}
+
+		obj, isVar := pass.TypesInfo.Uses[typed].(*types.Var)
+		if !isVar || isReassigned(obj, pass) {
+			return unknownSchemaValue{}, nil
+		}
+
+		if value := localDefinitionValue(obj, pass); value != nil {
+			return schemaValue(value, pass)
+		}
+	case *ast.CallExpr:
+		if len(typed.Args) == 1 && pass.TypesInfo.Types[typed.Fun].IsType() {
+			if data, known := constantString(typed.Args[0], pass); known {
+				var value any
+
+				err := json.Unmarshal([]byte(data), &value)
+
+				return value, err
+			}
+		}
+	case *ast.CompositeLit:
+		return compositeSchemaValue(typed, pass)
+	}
+
+	return unknownSchemaValue{}, nil
+}
+
+// compositeSchemaValue evaluates a map literal with string keys into a JSON
+// object and a slice or array literal into a JSON array.
+func compositeSchemaValue(literal *ast.CompositeLit, pass *analysis.Pass) (any, error) {
+	switch pass.TypesInfo.TypeOf(literal).Underlying().(type) {
+	case *types.Map:
+		object := make(map[string]any, len(literal.Elts))
+
+		for _, elt := range literal.Elts {
+			keyValue, isKeyValue := elt.(*ast.KeyValueExpr)
+			if !isKeyValue {
+				return unknownSchemaValue{}, nil
+			}
+
+			key, known := constantString(keyValue.Key, pass)
+			if !known {
+				return unknownSchemaValue{}, nil
+			}
+
+			value, err := schemaValue(keyValue.Value, pass)
+			if err != nil {
+				return nil, err
+			}
+
+			object[key] = value
+		}
+
+		return object, nil
+	case *types.Slice, *types.Array:
+		array := make([]any, 0, len(literal.Elts))
+
+		for _, elt := range literal.Elts {
+			if _, isKeyValue := elt.(*ast.KeyValueExpr); isKeyValue {
+				return unknownSchemaValue{}, nil
+			}
+
+			value, err := schemaValue(elt, pass)
+			if err != nil {
+				return nil, err
+			}
+
+			array = append(array, value)
+		}
+
+		return array, nil
+	default:
+		return unknownSchemaValue{}, nil
+	}
+}
+
+// constantSchemaValue converts a Go constant into a JSON value.
+func constantSchemaValue(value constant.Value) any {
+	switch value.Kind() {
+	case constant.String:
+		return constant.StringVal(value)
+	case constant.Bool:
+		return constant.BoolVal(value)
+	case constant.Int, constant.Float:
+		number, _ := constant.Float64Val(value)
+
+		return number
+	default:
+		return unknownSchemaValue{}
+	}
+}
+
+// schemaProblem returns the first problem of the JSON Schema draft-04 schema
+// at path, or an empty string.
+func schemaProblem(value any, path string) string {
+	schema, isObject := value.(map[string]any)
+	if !isObject {
+		if _, unknown := value.(unknownSchemaValue); unknown {
+			return ""
+		}
+
+		return path + " must be a schema object"
+	}
+
+	for _, keyword := range sortedKeys(schema) {
+		if problem := keywordProblem(keyword, schema[keyword], path+schemaPathSeparator+keyword); problem != "" {
+			return problem
+		}
+	}
+
+	return ""
+}
+
+// keywordProblem returns the problem of the value of a schema keyword at
+// path, or an empty string.
+//
+//nolint:cyclop,funlen // one case per draft-04 keyword
+func keywordProblem(keyword string, value any, path string) string {
+	if _, unknown := value.(unknownSchemaValue); unknown {
+		return ""
+	}
+
+	switch keyword {
+	case "type":
+		return typeKeywordProblem(value, path)
+	case "properties", "patternProperties", "definitions":
+		return schemaMapProblem(value, path)
+	case "items":
+		if _, isArray := value.([]any); isArray {
+			return schemaArrayProblem(value, path, false)
+		}
+
+		return schemaProblem(value, path)
+	case "additionalProperties", "additionalItems":
+		if _, isBool := value.(bool); isBool {
+			return ""
+		}
+
+		return schemaProblem(value, path)
+	case "not":
+		return schemaProblem(value, path)
+	case "allOf", "anyOf", "oneOf":
+		return schemaArrayProblem(value, path, true)
+	case "dependencies":
+		return dependenciesProblem(value, path)
+	case "required":
+		return stringArrayProblem(value, path)
+	case "enum":
+		if array, isArray := value.([]any); !isArray || len(array) == 0 {
+			return path + " must be a non-empty array"
+		}
+	case "minimum", "maximum":
+		if _, isNumber := value.(float64); !isNumber {
+			return path + " must be a number"
+		}
+	case "multipleOf":
+		if number, isNumber := value.(float64); !isNumber || number <= 0 {
+			return path + " must be a number greater than 0"
+		}
+	case "exclusiveMinimum", "exclusiveMaximum":
+		if _, isBool := value.(bool); !isBool {
+			return path + " must be a boolean in JSON Schema draft-04"
+		}
+	case "uniqueItems":
+		if _, isBool := value.(bool); !isBool {
+			return path + " must be a boolean"
+		}
+	case "minLength", "maxLength", "minItems", "maxItems", "minProperties", "maxProperties":
+		if number, isNumber := value.(float64); !isNumber || number < 0 || number != math.Trunc(number) {
+			return path + " must be a non-negative integer"
+		}
+	case "pattern", "format", "$ref", "$schema", "id", "title", "description":
+		if _, isString := value.(string); !isString {
+			return path + " must be a string"
+		}
+	default:
+		if slices.Contains(laterDraftKeywords, keyword) {
+			return path + " is not part of JSON Schema draft-04 and is ignored by ArangoDB"
+		}
+	}
+
+	return ""
+}
+
+// typeKeywordProblem checks a type keyword: a primitive type name or a
+// non-empty array of unique primitive type names.
+func typeKeywordProblem(value any, path string) string {
+	if name, isString := value.(string); isString {
+		if !slices.Contains(schemaTypes, name) {
+			return fmt.Sprintf("%s has unknown type %q", path, name)
+		}
+
+		return ""
+	}
+
+	if _, isArray := value.([]any); !isArray {
+		return path + " must be a type name or an array of type names"
+	}
+
+	if problem := stringArrayProblem(value, path); problem != "" {
+		return problem
+	}
+
+	for _, name := range value.([]any) { //nolint:forcetypeassert // checked above
+		if name, isString := name.(string); isString && !slices.Contains(schemaTypes, name) {
+			return fmt.Sprintf("%s has unknown type %q", path, name)
+		}
+	}
+
+	return ""
+}
+
+// schemaMapProblem checks an object whose values are schemas.
+func schemaMapProblem(value any, path string) string {
+	object, isObject := value.(map[string]any)
+	if !isObject {
+		return path + " must be an object of schemas"
+	}
+
+	for _, name := range sortedKeys(object) {
+		if problem := schemaProblem(object[name], path+schemaPathSeparator+name); problem != "" {
+			return problem
+		}
+	}
+
+	return ""
+}
+
+// schemaArrayProblem checks an array of schemas, which must not be empty when
+// nonEmpty is set.
+func schemaArrayProblem(value any, path string, nonEmpty bool) string {
+	array, isArray := value.([]any)
+	if !isArray || (nonEmpty && len(array) == 0) {
+		return path + " must be a non-empty array of schemas"
+	}
+
+	for index, item := range array {
+		if problem := schemaProblem(item, fmt.Sprintf("%s[%d]", path, index)); problem != "" {
+			return problem
+		}
+	}
+
+	return ""
+}
+
+// dependenciesProblem checks a dependencies keyword: an object whose values
+// are schemas or arrays of property names.
+func dependenciesProblem(value any, path string) string {
+	object, isObject := value.(map[string]any)
+	if !isObject {
+		return path + " must be an object"
+	}
+
+	for _, name := range sortedKeys(object) {
+		dependency := object[name]
+		if _, isArray := dependency.([]any); isArray {
+			if problem := stringArrayProblem(dependency, path+schemaPathSeparator+name); problem != "" {
+				return problem
+			}
+
+			continue
+		}
+
+		if problem := schemaProblem(dependency, path+schemaPathSeparator+name); problem != "" {
+			return problem
+		}
+	}
+
+	return ""
+}
+
+// stringArrayProblem checks a non-empty array of unique strings, as draft-04
+// requires for required, dependencies and type.
+func stringArrayProblem(value any, path string) string {
+	array, isArray := value.([]any)
+	if !isArray || len(array) == 0 {
+		return path + " must be a non-empty array of strings"
+	}
+
+	seen := make([]string, 0, len(array))
+
+	for _, item := range array {
+		if _, unknown := item.(unknownSchemaValue); unknown {
+			continue
+		}
+
+		name, isString := item.(string)
+		if !isString {
+			return path + " must be a non-empty array of strings"
+		}
+
+		if slices.Contains(seen, name) {
+			return fmt.Sprintf("%s lists %q twice", path, name)
+		}
+
+		seen = append(seen, name)
+	}
+
+	return ""
+}
+
+// sortedKeys returns the keys of object in order, so that the first problem
+// reported is stable.
+func sortedKeys(object map[string]any) []string {
+	keys := make([]string, 0, len(object))
+	for key := range object {
+		keys = append(keys, key)
+	}
+
+	slices.Sort(keys)
+
+	return keys
+}
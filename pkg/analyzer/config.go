@@ -0,0 +1,122 @@
+package analyzer
+
+import (
+	"flag"
+	"slices"
+	"strings"
+)
+
+const (
+	flagBlockingCalls              = "blocking-calls"
+	flagRequiredFields             = "required-fields"
+	flagDestructiveAllowedPackages = "destructive-allowed-packages"
+)
+
+// defaultBlockingCalls lists the qualified names (as printed by
+// types.Func.FullName) of calls known to block or hit the network.
+var defaultBlockingCalls = []string{
+	"time.Sleep",
+	"net.Dial",
+	"net.DialTimeout",
+	"net/http.Get",
+	"net/http.Head",
+	"net/http.Post",
+	"net/http.PostForm",
+	"(*net/http.Client).Do",
+	"(*net/http.Client).Get",
+	"(*net/http.Client).Head",
+	"(*net/http.Client).Post",
+	"(*net/http.Client).PostForm",
+	"(*sync.WaitGroup).Wait",
+	"(*os/exec.Cmd).Run",
+	"(*os/exec.Cmd).Output",
+	"(*os/exec.Cmd).CombinedOutput",
+}
+
+// config holds the analyzer settings.
+type config struct {
+	blockingCalls  stringList
+	requiredFields requiredFieldList
+	// destructiveAllowedPackages holds path.Match patterns of package paths.
+	destructiveAllowedPackages stringList
+}
+
+// newConfig returns the default settings.
+func newConfig() *config {
+	return &config{
+		blockingCalls:  slices.Clone(defaultBlockingCalls),
+		requiredFields: slices.Clone(defaultRequiredFields),
+	}
+}
+
+// registerFlags exposes the settings as analyzer flags.
+func (c *config) registerFlags(flags *flag.FlagSet) {
+	flags.Var(
+		&c.blockingCalls,
+		flagBlockingCalls,
+		"comma-separated qualified names of blocking calls reported while a transaction is open, added to the defaults",
+	)
+	flags.Var(
+		&c.requiredFields,
+		flagRequiredFields,
+		"comma-separated options fields (OptionsType.Field[.Field...][=message]) that driver calls must set explicitly, "+
+			"added to the defaults; messages cannot contain commas",
+	)
+	flags.Var(
+		&c.destructiveAllowedPackages,
+		flagDestructiveAllowedPackages,
+		"comma-separated package path patterns (such as */migrations) allowed to call destructive administrative operations",
+	)
+}
+
+// stringList is a flag.Value accumulating comma-separated values.
+type stringList []string
+
+func (l *stringList) String() string {
+	return strings.Join(*l, ",")
+}
+
+func (l *stringList) Set(value string) error {
+	for item := range strings.SplitSeq(value, ",") {
+		if item = strings.TrimSpace(item); item != "" && !slices.Contains(*l, item) {
+			*l = append(*l, item)
+		}
+	}
+
+	return nil
+}
+
+// requiredFieldList is a flag.Value accumulating comma-separated required fields.
+type requiredFieldList []requiredField
+
+func (l *requiredFieldList) String() string {
+	entries := make([]string, 0, len(*l))
+	for _, field := range *l {
+		entries = append(entries, field.String())
+	}
+
+	return strings.Join(entries, ",")
+}
+
+func (l *requiredFieldList) Set(value string) error {
+	for item := range strings.SplitSeq(value, ",") {
+		if item = strings.TrimSpace(item); item == "" {
+			continue
+		}
+
+		field, err := parseRequiredField(item)
+		if err != nil {
+			return err
+		}
+
+		// A later entry for the same field replaces its message.
+		index := slices.IndexFunc(*l, func(existing requiredField) bool { return existing.String() == field.String() })
+		if index < 0 {
+			*l = append(*l, field)
+		} else {
+			(*l)[index] = field
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,245 @@
+package analyzer
+
+import (
+	"go/ast"
+	"go/token"
+	"go/types"
+	"slices"
+
+	"golang.org/x/tools/go/analysis"
+)
+
+const (
+	msgCursorLoopUnbounded = "loop reads cursor %s without checking %s or shared.IsNoMoreDocuments"
+	cursorTypeName         = "Cursor"
+	cursorBatchTypeName    = "CursorBatch"
+	methodReadDocument     = "ReadDocument"
+	methodHasMore          = "HasMore"
+	methodHasMoreBatches   = "HasMoreBatches"
+)
+
+// cursorReadMethods maps the cursor types to their read method and the
+// method telling whether another read will succeed.
+var cursorReadMethods = map[string]struct{ read, hasMore string }{
+	cursorTypeName:      {read: methodReadDocument, hasMore: methodHasMore},
+	cursorBatchTypeName: {read: methodReadNextBatch, hasMore: methodHasMoreBatches},
+}
+
+// handleCursorLoop validates loops iterating an arangodb.Cursor or CursorBatch.
+// Such loops must be bounded by HasMore/HasMoreBatches (in the condition or the
+// body) or by a shared.IsNoMoreDocuments check on the read error guarding an
+// exit from the loop; otherwise the end of the cursor is treated as a fatal
+// error, or the loop never ends.
+func handleCursorLoop(loop *ast.ForStmt, pass *analysis.Pass) {
+	for _, read := range cursorReadsInLoop(loop, pass) {
+		methods := cursorReadMethods[read.typeName]
+
+		if callsCursorMethod(loop, read.receiver, methods.hasMore, pass) ||
+			exitsOnNoMoreDocuments(loop.Body, read.errObjs, pass) {
+			continue
+		}
+
+		pass.Reportf(loop.Pos(), msgCursorLoopUnbounded, read.receiver, methods.hasMore)
+	}
+}
+
+// cursorRead is a cursor read call found in a loop body.
+type cursorRead struct {
+	receiver string
+	typeName string
+	// errObjs lists the variables the read errors are assigned to.
+	errObjs []types.Object
+}
+
+// cursorReadsInLoop returns the distinct cursors read in the loop body,
+// identified by their receiver expression. Function literals are skipped as
+// they do not run as part of the loop iteration.
+func cursorReadsInLoop(loop *ast.ForStmt, pass *analysis.Pass) []cursorRead {
+	var reads []*cursorRead
+
+	readOf := func(call *ast.CallExpr) *cursorRead {
+		method := arangoMethod(call, pass)
+		if method == nil {
+			return nil
+		}
+
+		typeName := methodRecvTypeName(method)
+
+		methods, isCursor := cursorReadMethods[typeName]
+		if !isCursor || method.Name() != methods.read {
+			return nil
+		}
+
+		receiver := types.ExprString(call.Fun.(*ast.SelectorExpr).X) //nolint:forcetypeassert // checked by arangoMethod
+		for _, read := range reads {
+			if read.receiver == receiver {
+				return read
+			}
+		}
+
+		reads = append(reads, &cursorRead{receiver: receiver, typeName: typeName})
+
+		return reads[len(reads)-1]
+	}
+
+	ast.Inspect(loop.Body, func(node ast.Node) bool {
+		switch typed := node.(type) {
+		case *ast.FuncLit:
+			return false
+		case *ast.CallExpr:
+			readOf(typed)
+		case *ast.AssignStmt:
+			if len(typed.Rhs) != 1 {
+				return true
+			}
+
+			call, isCall := unwrapParens(typed.Rhs[0]).(*ast.CallExpr)
+			if !isCall {
+				return true
+			}
+
+			errIdent, isIdent := typed.Lhs[len(typed.Lhs)-1].(*ast.Ident)
+			if !isIdent {
+				return true
+			}
+
+			if read := readOf(call); read != nil {
+				if obj := pass.TypesInfo.ObjectOf(errIdent); obj != nil {
+					read.errObjs = append(read.errObjs, obj)
+				}
+			}
+		}
+
+		return true
+	})
+
+	result := make([]cursorRead, 0, len(reads))
+	for _, read := range reads {
+		result = append(result, *read)
+	}
+
+	return result
+}
+
+// exitsOnNoMoreDocuments reports whether body contains an if statement whose
+// condition calls shared.IsNoMoreDocuments on one of errObjs and whose body
+// leaves the loop. Function literals are skipped.
+func exitsOnNoMoreDocuments(body *ast.BlockStmt, errObjs []types.Object, pass *analysis.Pass) bool {
+	found := false
+
+	ast.Inspect(body, func(node ast.Node) bool {
+		switch typed := node.(type) {
+		case *ast.FuncLit:
+			return false
+		case *ast.IfStmt:
+			if testsNoMoreDocuments(typed.Cond, errObjs, pass) && leavesLoop(typed.Body) {
+				found = true
+			}
+		}
+
+		return !found
+	})
+
+	return found
+}
+
+// testsNoMoreDocuments reports whether cond is a shared.IsNoMoreDocuments call
+// on one of errObjs, possibly combined with other conditions using &&.
+func testsNoMoreDocuments(cond ast.Expr, errObjs []types.Object, pass *analysis.Pass) bool {
+	switch typed := unwrapParens(cond).(type) {
+	case *ast.BinaryExpr:
+		return typed.Op == token.LAND &&
+			(testsNoMoreDocuments(typed.X, errObjs, pass) || testsNoMoreDocuments(typed.Y, errObjs, pass))
+	case *ast.CallExpr:
+		if !isSharedFuncCall(typed, funcIsNoMoreDocuments, pass) || len(typed.Args) != 1 {
+			return false
+		}
+
+		id, isIdent := unwrapParens(typed.Args[0]).(*ast.Ident)
+
+		return isIdent && slices.Contains(errObjs, pass.TypesInfo.ObjectOf(id))
+	default:
+		return false
+	}
+}
+
+// leavesLoop reports whether block returns, jumps with goto or a labeled
+// break, or breaks out of the loop enclosing it. Unlabeled breaks inside
+// nested loops, switch and select statements do not leave the loop.
+func leavesLoop(block *ast.BlockStmt) bool {
+	found := false
+
+	var visit func(node ast.Node, nested bool)
+
+	visit = func(node ast.Node, nested bool) {
+		ast.Inspect(node, func(child ast.Node) bool {
+			if found || child == nil {
+				return false
+			}
+
+			switch typed := child.(type) {
+			case *ast.FuncLit:
+				return false
+			case *ast.ForStmt, *ast.RangeStmt, *ast.SwitchStmt, *ast.TypeSwitchStmt, *ast.SelectStmt:
+				if child != node {
+					visit(child, true)
+
+					return false
+				}
+			case *ast.ReturnStmt:
+				found = true
+			case *ast.BranchStmt:
+				found = typed.Tok == token.GOTO || (typed.Tok == token.BREAK && (typed.Label != nil || !nested))
+			}
+
+			return !found
+		})
+	}
+
+	visit(block, false)
+
+	return found
+}
+
+// methodRecvTypeName returns the name of the named type declaring method.
+func methodRecvTypeName(method *types.Func) string {
+	sig, isSignature := method.Type().(*types.Signature)
+	if !isSignature || sig.Recv() == nil {
+		return ""
+	}
+
+	recvType := sig.Recv().Type()
+	if ptr, isPointer := recvType.(*types.Pointer); isPointer {
+		recvType = ptr.Elem()
+	}
+
+	named, isNamed := recvType.(*types.Named)
+	if !isNamed {
+		return ""
+	}
+
+	return named.Obj().Name()
+}
+
+// callsCursorMethod reports whether node contains a call to methodName on the
+// receiver expression rendered as receiver.
+func callsCursorMethod(node ast.Node, receiver, methodName string, pass *analysis.Pass) bool {
+	found := false
+
+	ast.Inspect(node, func(child ast.Node) bool {
+		call, isCall := child.(*ast.CallExpr)
+		if !isCall {
+			return !found
+		}
+
+		method := arangoMethod(call, pass)
+		if method != nil && method.Name() == methodName &&
+			types.ExprString(call.Fun.(*ast.SelectorExpr).X) == receiver { //nolint:forcetypeassert // checked by arangoMethod
+			found = true
+		}
+
+		return !found
+	})
+
+	return found
+}
@@ -0,0 +1,110 @@
+package analyzer
+
+import (
+	"go/ast"
+	"path"
+	"slices"
+	"strings"
+
+	"golang.org/x/tools/go/analysis"
+)
+
+const (
+	msgDestructiveCall         = "%s is a destructive administrative operation; call it from an approved package or annotate it with //%s <reason>"
+	directiveAllowDestructive  = "arangolint:allow-destructive"
+	directivePrefix            = "//"
+	testFileSuffix             = "_test.go"
+	packagePathSeparator       = "/"
+	destructiveMethodSeparator = "."
+)
+
+// destructiveMethods lists the driver methods (receiver interface and method
+// name) that drop data, restore backups or change user permissions.
+var destructiveMethods = []string{
+	"Collection.Truncate",
+	"Collection.Remove",
+	"Database.Remove",
+	"ClientAdminBackup.BackupRestore",
+	"ClientAdminBackup.BackupDelete",
+	"ClientUsers.RemoveUser",
+	"UserPermissions.SetDatabaseAccess",
+	"UserPermissions.RemoveDatabaseAccess",
+	"UserPermissions.SetCollectionAccess",
+	"UserPermissions.RemoveCollectionAccess",
+}
+
+// handleDestructiveCall reports destructive administrative driver calls made
+// outside test files and outside the packages allowed with the
+// destructive-allowed-packages flag, unless annotated with
+// //arangolint:allow-destructive and a reason.
+func handleDestructiveCall(call *ast.CallExpr, pass *analysis.Pass, cfg *config) {
+	method := arangoMethod(call, pass)
+	if method == nil {
+		return
+	}
+
+	name := methodRecvTypeName(method) + destructiveMethodSeparator + method.Name()
+	if !slices.Contains(destructiveMethods, name) {
+		return
+	}
+
+	if strings.HasSuffix(pass.Fset.File(call.Pos()).Name(), testFileSuffix) ||
+		packageMatches(pass.Pkg.Path(), cfg.destructiveAllowedPackages) {
+		return
+	}
+
+	if reason, found := directiveReason(call, directiveAllowDestructive, pass); found && reason != "" {
+		return
+	}
+
+	pass.Reportf(call.Pos(), msgDestructiveCall, name, directiveAllowDestructive)
+}
+
+// packageMatches reports whether pkgPath, or one of its trailing segments,
+// matches one of the path.Match patterns. For example "*/migrations" matches
+// "example.com/app/migrations".
+func packageMatches(pkgPath string, patterns []string) bool {
+	for {
+		for _, pattern := range patterns {
+			if matched, err := path.Match(pattern, pkgPath); err == nil && matched {
+				return true
+			}
+		}
+
+		_, rest, found := strings.Cut(pkgPath, packagePathSeparator)
+		if !found {
+			return false
+		}
+
+		pkgPath = rest
+	}
+}
+
+// directiveReason looks for a //<directive> comment on the line of node or on
+// the line above, and returns the text following the directive.
+func directiveReason(node ast.Node, directive string, pass *analysis.Pass) (string, bool) {
+	file := fileAt(node.Pos(), pass)
+	if file == nil {
+		return "", false
+	}
+
+	line := pass.Fset.Position(node.Pos()).Line
+
+	for _, group := range file.Comments {
+		for _, comment := range group.List {
+			commentLine := pass.Fset.Position(comment.Pos()).Line
+			if commentLine != line && commentLine != line-1 {
+				continue
+			}
+
+			text, found := strings.CutPrefix(comment.Text, directivePrefix+directive)
+			if !found || (text != "" && text[0] != ' ' && text[0] != '\t') {
+				continue
+			}
+
+			return strings.TrimSpace(text), true
+		}
+	}
+
+	return "", false
+}
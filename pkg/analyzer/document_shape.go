@@ -0,0 +1,86 @@
+package analyzer
+
+import (
+	"go/ast"
+	"go/types"
+	"strings"
+
+	"golang.org/x/tools/go/analysis"
+)
+
+const (
+	msgBatchNotList      = "%s expects a slice or array of documents, got %s"
+	msgSingleGivenList   = "%s given a slice of documents; use %s"
+	singleDocumentSuffix = "Document"
+)
+
+// handleDocumentShapeCall validates the static shape of interface{} document
+// arguments: batch methods (CreateDocuments, ReadDocumentsWithOptions, ...) need
+// a slice or array at runtime, while single-document methods given a slice
+// were most likely meant to be their batch counterpart.
+func handleDocumentShapeCall(call *ast.CallExpr, pass *analysis.Pass) {
+	method := arangoMethod(call, pass)
+	if method == nil {
+		return
+	}
+
+	arg, paramName := documentArgument(call, method)
+	if arg == nil {
+		return
+	}
+
+	typeAndValue, known := pass.TypesInfo.Types[arg]
+	if !known || typeAndValue.Type == nil {
+		return
+	}
+
+	switch paramName {
+	case documentsParamName:
+		if typeAndValue.IsNil() || !isListOrUnknown(typeAndValue.Type) {
+			pass.Reportf(arg.Pos(), msgBatchNotList, method.Name(), typeAndValue.Type.String())
+		}
+	case documentParamName:
+		if isDocumentList(typeAndValue.Type) {
+			batchName := strings.Replace(method.Name(), singleDocumentSuffix, singleDocumentSuffix+"s", 1)
+			pass.Reportf(arg.Pos(), msgSingleGivenList, method.Name(), batchName)
+		}
+	}
+}
+
+// isListOrUnknown mirrors the driver's runtime check (a list, or a pointer to
+// a list) while staying conservative for interfaces and type parameters.
+func isListOrUnknown(t types.Type) bool {
+	if ptr, isPointer := t.Underlying().(*types.Pointer); isPointer {
+		t = ptr.Elem()
+	}
+
+	switch t.Underlying().(type) {
+	case *types.Slice, *types.Array, *types.Interface, *types.TypeParam:
+		return true
+	default:
+		return false
+	}
+}
+
+// isDocumentList reports whether t is a slice or array (or a pointer to one)
+// that is not raw bytes, such as json.RawMessage.
+func isDocumentList(t types.Type) bool {
+	if ptr, isPointer := t.Underlying().(*types.Pointer); isPointer {
+		t = ptr.Elem()
+	}
+
+	var elem types.Type
+
+	switch typed := t.Underlying().(type) {
+	case *types.Slice:
+		elem = typed.Elem()
+	case *types.Array:
+		elem = typed.Elem()
+	default:
+		return false
+	}
+
+	basic, isBasic := elem.Underlying().(*types.Basic)
+
+	return !isBasic || basic.Kind() != types.Byte
+}
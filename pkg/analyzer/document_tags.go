@@ -0,0 +1,295 @@
+package analyzer
+
+import (
+	"go/ast"
+	"go/types"
+	"reflect"
+	"slices"
+	"strings"
+
+	"golang.org/x/tools/go/analysis"
+)
+
+const (
+	msgSystemTagMissingOmitempty = "field %s.%s tags %q (%s) without omitempty"
+	msgSystemTagDuplicate        = "fields %s.%s and %s.%s both tag %q (%s)"
+	msgSystemTagIDOnInsert       = "field %s.%s tags %q (%s) on insert; the server assigns document ids"
+	systemAttrKey                = "_key"
+	systemAttrID                 = "_id"
+	systemAttrRev                = "_rev"
+	tagOmitempty                 = "omitempty"
+	documentParamName            = "document"
+	documentsParamName           = "documents"
+)
+
+// documentTagKeys lists the struct tag keys honored by the driver serializers.
+var documentTagKeys = []string{"json", "velocypack"}
+
+// documentWriteMethods maps document write methods to whether they insert documents.
+var documentWriteMethods = map[string]bool{
+	"CreateDocument":              true,
+	"CreateDocumentWithOptions":   true,
+	"CreateDocuments":             true,
+	"CreateDocumentsWithOptions":  true,
+	"ReplaceDocument":             false,
+	"ReplaceDocumentWithOptions":  false,
+	"ReplaceDocuments":            false,
+	"ReplaceDocumentsWithOptions": false,
+	"UpdateDocument":              false,
+	"UpdateDocumentWithOptions":   false,
+	"UpdateDocuments":             false,
+	"UpdateDocumentsWithOptions":  false,
+}
+
+// systemField is a struct field serialized as one of the _key, _id or _rev
+// system attributes under a given tag key.
+type systemField struct {
+	typeName   string
+	fieldName  string
+	attr       string
+	omitempty  bool
+	fromDriver bool
+	depth      int
+}
+
+// systemFieldProblem is a diagnostic about system fields, along with the tag
+// keys it was found under.
+type systemFieldProblem struct {
+	format  string
+	args    []string
+	tagKeys []string
+}
+
+// handleDocumentTagsCall validates the struct tags of documents passed to
+// CreateDocument/ReplaceDocument/UpdateDocument and their batch variants.
+// Only the static type of the document argument is inspected; slices, arrays
+// and pointers are unwrapped down to the element struct type.
+func handleDocumentTagsCall(call *ast.CallExpr, pass *analysis.Pass) {
+	method := arangoMethod(call, pass)
+	if method == nil {
+		return
+	}
+
+	isInsert, isDocumentWrite := documentWriteMethods[method.Name()]
+	if !isDocumentWrite {
+		return
+	}
+
+	arg, _ := documentArgument(call, method)
+	if arg == nil {
+		return
+	}
+
+	typeName, structType := documentStructType(pass.TypesInfo.TypeOf(arg))
+	if structType == nil {
+		return
+	}
+
+	// A field tagged for several serializers is reported once, naming the tag
+	// keys involved.
+	var problems []*systemFieldProblem
+
+	for _, tagKey := range documentTagKeys {
+		fields := collectSystemFields(structType, typeName, tagKey, 0, nil)
+
+		for _, problem := range systemFieldProblems(fields, isInsert) {
+			index := slices.IndexFunc(problems, func(existing *systemFieldProblem) bool {
+				return existing.format == problem.format && slices.Equal(existing.args, problem.args)
+			})
+			if index < 0 {
+				problems = append(problems, problem)
+				index = len(problems) - 1
+			}
+
+			problems[index].tagKeys = append(problems[index].tagKeys, tagKey)
+		}
+	}
+
+	for _, problem := range problems {
+		args := make([]any, 0, len(problem.args)+1)
+		for _, arg := range problem.args {
+			args = append(args, arg)
+		}
+
+		pass.Reportf(arg.Pos(), problem.format, append(args, strings.Join(problem.tagKeys, ", "))...)
+	}
+}
+
+// documentArgument returns the argument bound to the "document" or "documents"
+// parameter of method along with the parameter name, or nil when the call does
+// not have one.
+func documentArgument(call *ast.CallExpr, method *types.Func) (ast.Expr, string) {
+	sig, isSignature := method.Type().(*types.Signature)
+	if !isSignature {
+		return nil, ""
+	}
+
+	params := sig.Params()
+	for paramIndex := range params.Len() {
+		name := params.At(paramIndex).Name()
+		if name != documentParamName && name != documentsParamName {
+			continue
+		}
+
+		if paramIndex < len(call.Args) {
+			return call.Args[paramIndex], name
+		}
+	}
+
+	return nil, ""
+}
+
+// documentStructType unwraps pointers, slices and arrays around t and returns
+// the element struct type along with its display name. It returns a nil struct
+// when the element is not a struct (maps, interfaces, ...).
+func documentStructType(t types.Type) (string, *types.Struct) {
+	for t != nil {
+		switch typed := t.Underlying().(type) {
+		case *types.Pointer:
+			t = typed.Elem()
+		case *types.Slice:
+			t = typed.Elem()
+		case *types.Array:
+			t = typed.Elem()
+		case *types.Struct:
+			return typeDisplayName(t), typed
+		default:
+			return "", nil
+		}
+	}
+
+	return "", nil
+}
+
+// typeDisplayName returns the unqualified name of a named type, or its string
+// representation for unnamed ones.
+func typeDisplayName(t types.Type) string {
+	if named, isNamed := t.(*types.Named); isNamed {
+		return named.Obj().Name()
+	}
+
+	return t.String()
+}
+
+// collectSystemFields returns the fields of structType serialized as system
+// attributes under tagKey. Untagged embedded structs are flattened like
+// encoding/json does; visited guards against recursive embedding.
+func collectSystemFields(
+	structType *types.Struct,
+	typeName, tagKey string,
+	depth int,
+	visited []*types.Struct,
+) []systemField {
+	if slices.Contains(visited, structType) {
+		return nil
+	}
+
+	visited = append(visited, structType)
+
+	var fields []systemField
+
+	for fieldIndex := range structType.NumFields() {
+		field := structType.Field(fieldIndex)
+		name, options, tagged := parseFieldTag(structType.Tag(fieldIndex), tagKey)
+
+		if field.Anonymous() && !tagged {
+			embeddedName, embedded := embeddedStruct(field.Type())
+			if embedded != nil {
+				fields = append(fields, collectSystemFields(embedded, embeddedName, tagKey, depth+1, visited)...)
+
+				continue
+			}
+		}
+
+		if !isSystemAttr(name) {
+			continue
+		}
+
+		fields = append(fields, systemField{
+			typeName:   typeName,
+			fieldName:  field.Name(),
+			attr:       name,
+			omitempty:  hasTagOption(options, tagOmitempty),
+			fromDriver: field.Pkg() != nil && strings.HasSuffix(field.Pkg().Path(), arangoPackageSuffix),
+			depth:      depth,
+		})
+	}
+
+	return fields
+}
+
+// embeddedStruct returns the struct type behind an embedded field type,
+// dereferencing one level of pointer.
+func embeddedStruct(t types.Type) (string, *types.Struct) {
+	if ptr, isPointer := t.Underlying().(*types.Pointer); isPointer {
+		t = ptr.Elem()
+	}
+
+	structType, isStruct := t.Underlying().(*types.Struct)
+	if !isStruct {
+		return "", nil
+	}
+
+	return typeDisplayName(t), structType
+}
+
+// parseFieldTag returns the name and options of tagKey in tag, and whether the
+// tag explicitly names the field.
+func parseFieldTag(tag, tagKey string) (name, options string, tagged bool) {
+	value, found := reflect.StructTag(tag).Lookup(tagKey)
+	if !found {
+		return "", "", false
+	}
+
+	name, options, _ = strings.Cut(value, ",")
+
+	return name, options, name != ""
+}
+
+// hasTagOption reports whether the comma separated options contain option.
+func hasTagOption(options, option string) bool {
+	return slices.Contains(strings.Split(options, ","), option)
+}
+
+// isSystemAttr reports whether name is a system attribute settable by clients.
+func isSystemAttr(name string) bool {
+	return name == systemAttrKey || name == systemAttrID || name == systemAttrRev
+}
+
+// systemFieldProblems returns the missing omitempty options, duplicated system
+// attributes at the same embedding depth and _id fields sent on insert among
+// the fields collected under one tag key.
+func systemFieldProblems(fields []systemField, isInsert bool) []*systemFieldProblem {
+	var problems []*systemFieldProblem
+
+	for fieldIndex, field := range fields {
+		if !field.fromDriver && !field.omitempty {
+			problems = append(problems, &systemFieldProblem{
+				format: msgSystemTagMissingOmitempty,
+				args:   []string{field.typeName, field.fieldName, field.attr},
+			})
+		}
+
+		if isInsert && !field.fromDriver && field.attr == systemAttrID {
+			problems = append(problems, &systemFieldProblem{
+				format: msgSystemTagIDOnInsert,
+				args:   []string{field.typeName, field.fieldName, field.attr},
+			})
+		}
+
+		for _, previous := range fields[:fieldIndex] {
+			if previous.attr != field.attr || previous.depth != field.depth {
+				continue
+			}
+
+			problems = append(problems, &systemFieldProblem{
+				format: msgSystemTagDuplicate,
+				args:   []string{previous.typeName, previous.fieldName, field.typeName, field.fieldName, field.attr},
+			})
+
+			break
+		}
+	}
+
+	return problems
+}
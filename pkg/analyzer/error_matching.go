@@ -0,0 +1,432 @@
+package analyzer
+
+import (
+	"fmt"
+	"go/ast"
+	"go/constant"
+	"go/token"
+	"go/types"
+	"slices"
+	"strconv"
+	"strings"
+
+	"golang.org/x/tools/go/analysis"
+)
+
+const (
+	msgErrorMessageMatch = "driver error matched on its message; use %s"
+	msgErrorFieldCompare = "ArangoError.%s compared with %d; use %s"
+	arangoErrorTypeName  = "ArangoError"
+	fieldCode            = "Code"
+	fieldErrorNum        = "ErrorNum"
+	funcIsArangoError    = "IsArangoError"
+	funcWithCode         = "IsArangoErrorWithCode"
+	funcWithErrorNum     = "IsArangoErrorWithErrorNum"
+	funcErrorsAs         = "As"
+	methodError          = "Error"
+	sharedQualifier      = "shared"
+	stringsPackagePath   = "strings"
+	errorsPackagePath    = "errors"
+	errorPlaceholder     = "err"
+	firstErrorNum        = 1000
+)
+
+// arangoErrorKind maps an ArangoDB HTTP code or errorNum to the shared
+// predicate testing for it.
+type arangoErrorKind struct {
+	code     int
+	errorNum int
+	// constant is the shared constant naming errorNum.
+	constant string
+	// texts are lowercase fragments of the error message.
+	texts []string
+	// predicate is the shared predicate testing code.
+	predicate string
+	// exact is true when predicate tests code and nothing else.
+	exact bool
+}
+
+// arangoErrorKinds is the table of known ArangoDB errors.
+var arangoErrorKinds = []arangoErrorKind{
+	{code: 400, texts: []string{"bad request"}, predicate: "IsInvalidRequest", exact: true},
+	{code: 401, texts: []string{"unauthorized"}, predicate: "IsUnauthorized", exact: true},
+	{code: 403, texts: []string{"forbidden"}, predicate: "IsForbidden", exact: true},
+	{code: 404, texts: []string{"not found"}, predicate: "IsNotFound"},
+	{code: 409, texts: []string{"conflict"}, predicate: "IsConflict"},
+	{code: 412, texts: []string{"precondition failed"}, predicate: "IsPreconditionFailed"},
+	{errorNum: 1200, constant: "ErrArangoConflict", texts: []string{"write-write conflict"}},
+	{errorNum: 1202, constant: "ErrArangoDocumentNotFound", texts: []string{"document not found"}},
+	{
+		errorNum: 1203, constant: "ErrArangoDataSourceNotFound",
+		texts: []string{"collection or view not found", "data source not found"},
+	},
+	{errorNum: 1210, constant: "ErrArangoUniqueConstraintViolated", texts: []string{"unique constraint violated"}},
+	{errorNum: 1228, constant: "ErrArangoDatabaseNotFound", texts: []string{"database not found"}},
+	{errorNum: 1702, constant: "ErrUserDuplicate", texts: []string{"duplicate user"}},
+}
+
+// stringMatchFuncs lists the strings functions used to match error messages.
+var stringMatchFuncs = []string{"Contains", "HasPrefix", "HasSuffix", "EqualFold"}
+
+// handleErrorMatchCall reports strings.Contains (and similar) called on the
+// message of an error returned by a driver call.
+func handleErrorMatchCall(call *ast.CallExpr, pass *analysis.Pass, stack []ast.Node) {
+	fn := calledFunc(call, pass)
+	if fn == nil || fn.Pkg() == nil || fn.Pkg().Path() != stringsPackagePath ||
+		!slices.Contains(stringMatchFuncs, fn.Name()) || len(call.Args) != 2 { //nolint:mnd // message and fragment
+		return
+	}
+
+	reportErrorMessageMatch(call, call.Args[0], call.Args[1], pass, stack)
+}
+
+// handleErrorComparison reports comparisons of driver error messages with
+// strings, and of ArangoError.Code or ArangoError.ErrorNum with numbers. The
+// latter come with a suggested fix when the matching predicate is exact.
+func handleErrorComparison(bin *ast.BinaryExpr, pass *analysis.Pass, stack []ast.Node) {
+	if bin.Op != token.EQL && bin.Op != token.NEQ {
+		return
+	}
+
+	for _, sides := range [][2]ast.Expr{{bin.X, bin.Y}, {bin.Y, bin.X}} {
+		if errorMessageOf(sides[0], pass) != nil {
+			reportErrorMessageMatch(bin, sides[0], sides[1], pass, stack)
+
+			return
+		}
+
+		if sel, isSelector := unwrapParens(sides[0]).(*ast.SelectorExpr); isSelector && isArangoErrorField(sel, pass) {
+			reportErrorFieldCompare(bin, sel, sides[1], pass, stack)
+
+			return
+		}
+	}
+}
+
+// reportErrorMessageMatch reports node when message is the message of a driver
+// error and fragment a string constant.
+func reportErrorMessageMatch(node ast.Node, message, fragment ast.Expr, pass *analysis.Pass, stack []ast.Node) {
+	errExpr := errorMessageOf(message, pass)
+	if errExpr == nil || !isDriverError(errExpr, stack, pass) {
+		return
+	}
+
+	value, known := constantString(fragment, pass)
+	if !known {
+		return
+	}
+
+	errText := types.ExprString(errExpr)
+
+	suggestion := sharedQualifier + "." + funcWithCode + " or " + sharedQualifier + "." + funcWithErrorNum
+	if kind, found := errorKindForText(value); found {
+		suggestion, _ = kind.suggestion(errText, sharedQualifier)
+	}
+
+	pass.Reportf(node.Pos(), msgErrorMessageMatch, suggestion)
+}
+
+// reportErrorFieldCompare reports bin, comparing the Code or ErrorNum field
+// selected by sel with value.
+func reportErrorFieldCompare(bin *ast.BinaryExpr, sel *ast.SelectorExpr, value ast.Expr, pass *analysis.Pass, stack []ast.Node) {
+	tv, found := pass.TypesInfo.Types[unwrapParens(value)]
+	if !found || tv.Value == nil || tv.Value.Kind() != constant.Int {
+		return
+	}
+
+	number, isInt := constant.Int64Val(tv.Value)
+	if !isInt {
+		return
+	}
+
+	errExpr := arangoErrorSource(sel.X, stack, pass)
+
+	errText := errorPlaceholder
+	if errExpr != nil {
+		errText = types.ExprString(errExpr)
+	}
+
+	kind := errorKindForNumber(sel.Sel.Name, int(number))
+	suggestion, exact := kind.suggestion(errText, sharedQualifier)
+
+	diag := analysis.Diagnostic{
+		Pos:     bin.Pos(),
+		End:     bin.End(),
+		Message: fmt.Sprintf(msgErrorFieldCompare, sel.Sel.Name, number, suggestion),
+	}
+
+	qualifier := sharedImportName(fileAt(bin.Pos(), pass))
+	if exact && errExpr != nil && qualifier != "" && !onlyUseOfVar(sel.X, bin, stack, pass) {
+		replacement, _ := kind.suggestion(errText, qualifier)
+		if bin.Op == token.NEQ {
+			replacement = "!" + replacement
+		}
+
+		diag.SuggestedFixes = []analysis.SuggestedFix{{
+			Message: "Use " + replacement,
+			TextEdits: []analysis.TextEdit{{
+				Pos:     bin.Pos(),
+				End:     bin.End(),
+				NewText: []byte(replacement),
+			}},
+		}}
+	}
+
+	pass.Report(diag)
+}
+
+// suggestion returns the shared call testing errText for the error kind, and
+// whether it is equivalent to comparing the code or errorNum.
+func (k arangoErrorKind) suggestion(errText, qualifier string) (string, bool) {
+	switch {
+	case k.errorNum != 0 && k.constant != "":
+		return fmt.Sprintf("%s.%s(%s, %s.%s)", qualifier, funcWithErrorNum, errText, qualifier, k.constant), true
+	case k.errorNum != 0:
+		return fmt.Sprintf("%s.%s(%s, %d)", qualifier, funcWithErrorNum, errText, k.errorNum), true
+	case k.predicate != "":
+		return fmt.Sprintf("%s.%s(%s)", qualifier, k.predicate, errText), k.exact
+	default:
+		return fmt.Sprintf("%s.%s(%s, %d)", qualifier, funcWithCode, errText, k.code), true
+	}
+}
+
+// errorKindForNumber returns the table entry for the code or errorNum
+// compared with field, or an entry without predicate for unknown numbers.
+func errorKindForNumber(field string, number int) arangoErrorKind {
+	for _, kind := range arangoErrorKinds {
+		if (field == fieldCode && kind.code == number) || (field == fieldErrorNum && kind.errorNum == number) {
+			return kind
+		}
+	}
+
+	if field == fieldErrorNum {
+		return arangoErrorKind{errorNum: number}
+	}
+
+	return arangoErrorKind{code: number}
+}
+
+// errorKindForText returns the table entry matching a fragment of an error
+// message: a code or errorNum, or the longest known message fragment.
+func errorKindForText(value string) (arangoErrorKind, bool) {
+	value = strings.ToLower(strings.TrimSpace(value))
+
+	if number, err := strconv.Atoi(value); err == nil {
+		if number >= firstErrorNum {
+			return errorKindForNumber(fieldErrorNum, number), true
+		}
+
+		return errorKindForNumber(fieldCode, number), true
+	}
+
+	var (
+		best    arangoErrorKind
+		bestLen int
+	)
+
+	for _, kind := range arangoErrorKinds {
+		for _, text := range kind.texts {
+			if len(text) > bestLen && strings.Contains(value, text) {
+				best, bestLen = kind, len(text)
+			}
+		}
+	}
+
+	return best, bestLen > 0
+}
+
+// errorMessageOf returns X when expr is X.Error(), possibly wrapped in
+// strings.ToLower or strings.ToUpper, with X an error.
+func errorMessageOf(expr ast.Expr, pass *analysis.Pass) ast.Expr {
+	call, isCall := unwrapParens(expr).(*ast.CallExpr)
+	if !isCall {
+		return nil
+	}
+
+	if fn := calledFunc(call, pass); fn != nil && fn.Pkg() != nil && fn.Pkg().Path() == stringsPackagePath &&
+		(fn.Name() == "ToLower" || fn.Name() == "ToUpper") && len(call.Args) == 1 {
+		return errorMessageOf(call.Args[0], pass)
+	}
+
+	sel, isSelector := call.Fun.(*ast.SelectorExpr)
+	if !isSelector || sel.Sel.Name != methodError || len(call.Args) != 0 {
+		return nil
+	}
+
+	errorType := types.Universe.Lookup("error").Type()
+	if t := pass.TypesInfo.TypeOf(sel.X); t == nil || !types.Implements(t, errorType.Underlying().(*types.Interface)) {
+		return nil
+	}
+
+	return sel.X
+}
+
+// isDriverError reports whether errExpr is a variable assigned the error of an
+// arangodb call in the enclosing function.
+func isDriverError(errExpr ast.Expr, stack []ast.Node, pass *analysis.Pass) bool {
+	id, isIdent := unwrapParens(errExpr).(*ast.Ident)
+	if !isIdent {
+		return false
+	}
+
+	obj := pass.TypesInfo.ObjectOf(id)
+	body := funcBody(enclosingFunc(stack))
+
+	if obj == nil || body == nil {
+		return false
+	}
+
+	found := false
+
+	ast.Inspect(body, func(node ast.Node) bool {
+		assign, isAssign := node.(*ast.AssignStmt)
+		if !isAssign || len(assign.Rhs) != 1 {
+			return !found
+		}
+
+		call, isCall := unwrapParens(assign.Rhs[0]).(*ast.CallExpr)
+		if !isCall || arangoMethod(call, pass) == nil {
+			return !found
+		}
+
+		for _, lhs := range assign.Lhs {
+			if lhsIdent, isLhsIdent := lhs.(*ast.Ident); isLhsIdent && pass.TypesInfo.ObjectOf(lhsIdent) == obj {
+				found = true
+			}
+		}
+
+		return !found
+	})
+
+	return found
+}
+
+// isArangoErrorField reports whether sel selects the Code or ErrorNum field of
+// a shared.ArangoError.
+func isArangoErrorField(sel *ast.SelectorExpr, pass *analysis.Pass) bool {
+	if sel.Sel.Name != fieldCode && sel.Sel.Name != fieldErrorNum {
+		return false
+	}
+
+	named, isNamed := deref(pass.TypesInfo.TypeOf(sel.X)).(*types.Named)
+
+	return isNamed && named.Obj().Pkg() != nil && named.Obj().Name() == arangoErrorTypeName &&
+		strings.HasSuffix(named.Obj().Pkg().Path(), sharedPackageSuffix)
+}
+
+// arangoErrorSource returns the error an ArangoError value was extracted from:
+// the operand of err.(shared.ArangoError), or the error given to errors.As or
+// shared.IsArangoError to set the variable expr.
+func arangoErrorSource(expr ast.Expr, stack []ast.Node, pass *analysis.Pass) ast.Expr {
+	expr = unwrapParens(expr)
+
+	if assertion, isAssertion := expr.(*ast.TypeAssertExpr); isAssertion {
+		return assertion.X
+	}
+
+	id, isIdent := expr.(*ast.Ident)
+	body := funcBody(enclosingFunc(stack))
+
+	if !isIdent || body == nil {
+		return nil
+	}
+
+	obj := pass.TypesInfo.ObjectOf(id)
+
+	var source ast.Expr
+
+	ast.Inspect(body, func(node ast.Node) bool {
+		switch typed := node.(type) {
+		case *ast.CallExpr:
+			fn := calledFunc(typed, pass)
+			if fn != nil && fn.Pkg() != nil && fn.Pkg().Path() == errorsPackagePath && fn.Name() == funcErrorsAs &&
+				len(typed.Args) == 2 && pointsTo(typed.Args[1], obj, pass) { //nolint:mnd // err and target
+				source = typed.Args[0]
+			}
+		case *ast.AssignStmt:
+			if len(typed.Lhs) != 2 || len(typed.Rhs) != 1 { //nolint:mnd // ok and value
+				return source == nil
+			}
+
+			call, isCall := unwrapParens(typed.Rhs[0]).(*ast.CallExpr)
+			if !isCall || len(call.Args) != 1 || !isSharedFuncCall(call, funcIsArangoError, pass) {
+				return source == nil
+			}
+
+			if lhs, isLhsIdent := typed.Lhs[1].(*ast.Ident); isLhsIdent && pass.TypesInfo.ObjectOf(lhs) == obj {
+				source = call.Args[0]
+			}
+		}
+
+		return source == nil
+	})
+
+	return source
+}
+
+// onlyUseOfVar reports whether expr is a variable not used outside node in the
+// enclosing function, so that replacing node would leave it unused.
+func onlyUseOfVar(expr ast.Expr, node ast.Node, stack []ast.Node, pass *analysis.Pass) bool {
+	id, isIdent := unwrapParens(expr).(*ast.Ident)
+	body := funcBody(enclosingFunc(stack))
+
+	if !isIdent || body == nil {
+		return false
+	}
+
+	obj := pass.TypesInfo.ObjectOf(id)
+	usedElsewhere := false
+
+	ast.Inspect(body, func(child ast.Node) bool {
+		if child == node {
+			return false
+		}
+
+		if use, isUse := child.(*ast.Ident); isUse && pass.TypesInfo.Uses[use] == obj {
+			usedElsewhere = true
+		}
+
+		return !usedElsewhere
+	})
+
+	return !usedElsewhere
+}
+
+// pointsTo reports whether expr is &obj.
+func pointsTo(expr ast.Expr, obj types.Object, pass *analysis.Pass) bool {
+	unary, isUnary := unwrapParens(expr).(*ast.UnaryExpr)
+	if !isUnary || unary.Op != token.AND {
+		return false
+	}
+
+	id, isIdent := unwrapParens(unary.X).(*ast.Ident)
+
+	return isIdent && pass.TypesInfo.ObjectOf(id) == obj
+}
+
+// sharedImportName returns the name under which file imports the arangodb
+// shared package, or an empty string when it does not.
+func sharedImportName(file *ast.File) string {
+	if file == nil {
+		return ""
+	}
+
+	for _, imp := range file.Imports {
+		path, err := strconv.Unquote(imp.Path.Value)
+		if err != nil || !strings.HasSuffix(path, sharedPackageSuffix) {
+			continue
+		}
+
+		if imp.Name != nil {
+			if imp.Name.Name == "_" || imp.Name.Name == "." {
+				return ""
+			}
+
+			return imp.Name.Name
+		}
+
+		return sharedQualifier
+	}
+
+	return ""
+}
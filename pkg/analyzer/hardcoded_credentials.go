@@ -0,0 +1,127 @@
+package analyzer
+
+import (
+	"go/ast"
+	"go/constant"
+	"go/token"
+	"go/types"
+	"strings"
+
+	"golang.org/x/tools/go/analysis"
+)
+
+const msgHardcodedCredential = "hardcoded %s passed to connection.%s; load credentials from the environment or a secret store"
+
+// credentialArguments maps the authentication constructors of the connection
+// package to the index of their secret argument.
+var credentialArguments = map[string]int{
+	"NewBasicAuth":      1,
+	"NewJWTAuthWrapper": 1,
+	"NewHeaderAuth":     1,
+}
+
+// handleHardcodedCredentialCall reports authentication constructors called
+// with a non-empty constant secret: a literal, a constant, or a variable
+// initialized with one and never reassigned. Test files are ignored.
+func handleHardcodedCredentialCall(call *ast.CallExpr, pass *analysis.Pass) {
+	fn := calledFunc(call, pass)
+	if fn == nil || fn.Pkg() == nil || !strings.HasSuffix(fn.Pkg().Path(), connectionPackageSuffix) {
+		return
+	}
+
+	argIndex, isAuth := credentialArguments[fn.Name()]
+
+	sig, isSignature := fn.Type().(*types.Signature)
+	if !isAuth || !isSignature || len(call.Args) <= argIndex {
+		return
+	}
+
+	if strings.HasSuffix(pass.Fset.File(call.Pos()).Name(), testFileSuffix) {
+		return
+	}
+
+	secretName := sig.Params().At(argIndex).Name()
+
+	// NewHeaderAuth formats its value with the extra arguments, which then
+	// carry the secret.
+	if sig.Variadic() && len(call.Args) >= sig.Params().Len() {
+		if call.Ellipsis.IsValid() {
+			return
+		}
+
+		for _, arg := range call.Args[sig.Params().Len()-1:] {
+			if value, known := constantString(arg, pass); known && value != "" {
+				pass.Reportf(arg.Pos(), msgHardcodedCredential, secretName, fn.Name())
+			}
+		}
+
+		return
+	}
+
+	if value, known := constantString(call.Args[argIndex], pass); known && value != "" {
+		pass.Reportf(call.Args[argIndex].Pos(), msgHardcodedCredential, secretName, fn.Name())
+	}
+}
+
+// constantString returns the value of expr when it is a string constant, or a
+// variable initialized with one and never reassigned in the package.
+func constantString(expr ast.Expr, pass *analysis.Pass) (string, bool) {
+	expr = unwrapParens(expr)
+
+	if tv, found := pass.TypesInfo.Types[expr]; found && tv.Value != nil {
+		if tv.Value.Kind() != constant.String {
+			return "", false
+		}
+
+		return constant.StringVal(tv.Value), true
+	}
+
+	id, isIdent := expr.(*ast.Ident)
+	if !isIdent {
+		return "", false
+	}
+
+	obj, isVar := pass.TypesInfo.Uses[id].(*types.Var)
+	if !isVar || isReassigned(obj, pass) {
+		return "", false
+	}
+
+	value := localDefinitionValue(obj, pass)
+	if value == nil {
+		return "", false
+	}
+
+	if tv, found := pass.TypesInfo.Types[unwrapParens(value)]; found && tv.Value != nil &&
+		tv.Value.Kind() == constant.String {
+		return constant.StringVal(tv.Value), true
+	}
+
+	return "", false
+}
+
+// isReassigned reports whether obj is assigned after its declaration, or has
+// its address taken, anywhere in the package.
+func isReassigned(obj types.Object, pass *analysis.Pass) bool {
+	reassigned := false
+
+	for _, file := range pass.Files {
+		ast.Inspect(file, func(node ast.Node) bool {
+			switch typed := node.(type) {
+			case *ast.AssignStmt:
+				for _, lhs := range typed.Lhs {
+					if id, isIdent := lhs.(*ast.Ident); isIdent && pass.TypesInfo.Uses[id] == obj {
+						reassigned = true
+					}
+				}
+			case *ast.UnaryExpr:
+				if id, isIdent := typed.X.(*ast.Ident); isIdent && typed.Op == token.AND && pass.TypesInfo.Uses[id] == obj {
+					reassigned = true
+				}
+			}
+
+			return !reassigned
+		})
+	}
+
+	return reassigned
+}
@@ -0,0 +1,275 @@
+package analyzer
+
+import (
+	"go/ast"
+	"slices"
+	"strings"
+
+	"golang.org/x/tools/go/analysis"
+)
+
+const (
+	msgUnindexedFilter = "query filters collection %q on %s, which no declared index covers"
+	msgUnindexedSort   = "query sorts collection %q on %s, which no declared index covers"
+	methodEnsureMDI    = "EnsureMDIIndex"
+)
+
+// collectionSources lists the methods returning a collection by name.
+var collectionSources = []string{"Collection", "GetCollection", "CreateCollection", "CreateCollectionWithOptions"}
+
+// indexedSystemAttributes lists the attributes served by the primary and edge
+// indexes every collection has.
+var indexedSystemAttributes = []string{"_key", "_id", "_from", "_to"}
+
+// declaredIndex is an index declared with an Ensure*Index call.
+type declaredIndex struct {
+	Fields []string
+	// Ordered is set for persistent indexes, which serve a field only when
+	// the fields before it are filtered too. Multi-dimensional indexes serve
+	// any of their fields.
+	Ordered bool
+}
+
+// indexDeclarations is a package fact recording the indexes a package
+// declares, per collection name.
+type indexDeclarations struct {
+	Collections map[string][]declaredIndex
+}
+
+// AFact marks indexDeclarations as an analysis fact.
+func (*indexDeclarations) AFact() {}
+
+func (facts *indexDeclarations) String() string {
+	names := make([]string, 0, len(facts.Collections))
+	for name := range facts.Collections {
+		names = append(names, name)
+	}
+
+	slices.Sort(names)
+
+	return "indexDeclarations(" + strings.Join(names, ", ") + ")"
+}
+
+// reportIndexCoverage records the indexes declared by the package as a fact,
+// and reports the constant queries passed to Database or Transaction query
+// methods that filter or sort a collection on attributes no index declared by
+// the package or its dependencies covers. Only collections with an index
+// declared by a dependency are checked: the indexes of a collection declared
+// by the package alone are likely completed in packages it does not import,
+// such as migrations run by another binary, which are never seen.
+func reportIndexCoverage(pass *analysis.Pass) {
+	local := collectIndexDeclarations(pass)
+	if len(local.Collections) > 0 {
+		pass.ExportPackageFact(local)
+	}
+
+	// AllPackageFacts includes the facts of indirect dependencies.
+	declarations := &indexDeclarations{Collections: map[string][]declaredIndex{}}
+
+	for _, fact := range pass.AllPackageFacts() {
+		imported, isDeclarations := fact.Fact.(*indexDeclarations)
+		if !isDeclarations || fact.Package == pass.Pkg {
+			continue
+		}
+
+		for name, indexes := range imported.Collections {
+			declarations.Collections[name] = append(slices.Clip(declarations.Collections[name]), indexes...)
+		}
+	}
+
+	for name, indexes := range local.Collections {
+		if _, imported := declarations.Collections[name]; imported {
+			declarations.Collections[name] = append(slices.Clip(declarations.Collections[name]), indexes...)
+		}
+	}
+
+	if len(declarations.Collections) == 0 {
+		return
+	}
+
+	for _, file := range pass.Files {
+		ast.Inspect(file, func(node ast.Node) bool {
+			call, isCall := node.(*ast.CallExpr)
+			if !isCall {
+				return true
+			}
+
+			methodName, queryArgIndex := identifyQueryMethod(call, pass)
+			if methodName == "" || len(call.Args) <= queryArgIndex {
+				return true
+			}
+
+			if query, known := constantString(call.Args[queryArgIndex], pass); known {
+				checkIndexCoverage(call.Args[queryArgIndex], parseAQL(query), declarations, pass)
+			}
+
+			return true
+		})
+	}
+}
+
+// collectIndexDeclarations gathers the persistent and multi-dimensional
+// indexes the package declares on collections with a constant name.
+func collectIndexDeclarations(pass *analysis.Pass) *indexDeclarations {
+	declarations := &indexDeclarations{Collections: map[string][]declaredIndex{}}
+
+	for _, file := range pass.Files {
+		ast.Inspect(file, func(node ast.Node) bool {
+			call, isCall := node.(*ast.CallExpr)
+			if !isCall {
+				return true
+			}
+
+			method := arangoMethod(call, pass)
+			if method == nil {
+				return true
+			}
+
+			index, isIndex := declaredIndexOf(call, method.Name(), pass)
+			if !isIndex {
+				return true
+			}
+
+			if name, known := receiverCollectionName(call, pass); known {
+				declarations.Collections[name] = append(declarations.Collections[name], index)
+			}
+
+			return true
+		})
+	}
+
+	return declarations
+}
+
+// declaredIndexOf returns the index declared by an Ensure*Index call on a
+// collection, when its fields are constant.
+func declaredIndexOf(call *ast.CallExpr, methodName string, pass *analysis.Pass) (declaredIndex, bool) {
+	if len(call.Args) < 2 { //nolint:mnd // context and fields
+		return declaredIndex{}, false
+	}
+
+	fields, known := indexFieldNames(call.Args[1], pass)
+	if !known {
+		return declaredIndex{}, false
+	}
+
+	switch methodName {
+	case "EnsurePersistentIndex":
+		return declaredIndex{Fields: fields, Ordered: true}, true
+	case methodEnsureMDI, methodEnsureZKDIndex:
+		return declaredIndex{Fields: fields}, true
+	case methodEnsureMDIPrefixed:
+		if len(call.Args) > 2 { //nolint:mnd // context, fields and options
+			if value := optionsFieldValue(call.Args[2], prefixFieldsFieldName, pass); value != nil {
+				prefixFields, _ := indexFieldNames(value, pass)
+				fields = append(prefixFields, fields...)
+			}
+		}
+
+		return declaredIndex{Fields: fields}, true
+	default:
+		return declaredIndex{}, false
+	}
+}
+
+// receiverCollectionName returns the name of the collection a method is called
+// on, when the receiver is a variable initialized by a Collection,
+// GetCollection or CreateCollection call with a constant name.
+func receiverCollectionName(call *ast.CallExpr, pass *analysis.Pass) (string, bool) {
+	selExpr, isSelector := call.Fun.(*ast.SelectorExpr)
+	if !isSelector {
+		return "", false
+	}
+
+	id, isIdent := unwrapParens(selExpr.X).(*ast.Ident)
+	if !isIdent {
+		return "", false
+	}
+
+	source, isCall := unwrapParens(localDefinitionValue(pass.TypesInfo.ObjectOf(id), pass)).(*ast.CallExpr)
+	if !isCall {
+		return "", false
+	}
+
+	method := arangoMethod(source, pass)
+	if method == nil || !slices.Contains(collectionSources, method.Name()) || len(source.Args) < 2 { //nolint:mnd // context and name
+		return "", false
+	}
+
+	return constantString(source.Args[1], pass)
+}
+
+// checkIndexCoverage reports the loops of query over known collections whose
+// filters are not served by any index, and whose sort attribute is not.
+func checkIndexCoverage(queryArg ast.Expr, query *aqlQuery, declarations *indexDeclarations, pass *analysis.Pass) {
+	for _, loop := range query.loops {
+		indexes, known := declarations.Collections[loop.collection]
+		if loop.collection == "" || !known || len(loop.bodyClauses(aqlKeywordSearch)) > 0 {
+			continue
+		}
+
+		var filtered []string
+
+		for _, clause := range loop.bodyClauses(aqlKeywordFilter) {
+			for _, attribute := range clause.attributes(loop.variable) {
+				if !slices.Contains(filtered, attribute.String()) {
+					filtered = append(filtered, attribute.String())
+				}
+			}
+		}
+
+		if len(filtered) > 0 && !slices.ContainsFunc(filtered, func(attribute string) bool {
+			return indexCovers(indexes, attribute, filtered, false)
+		}) {
+			pass.Reportf(queryArg.Pos(), msgUnindexedFilter, loop.collection, strings.Join(filtered, ", "))
+		}
+
+		if sorted := sortAttribute(loop); sorted != "" && !indexCovers(indexes, sorted, filtered, true) {
+			pass.Reportf(queryArg.Pos(), msgUnindexedSort, loop.collection, sorted)
+		}
+	}
+}
+
+// sortAttribute returns the first attribute the loop sorts on, when the first
+// SORT criterion is an attribute of the loop variable.
+func sortAttribute(loop *aqlLoop) string {
+	for _, clause := range loop.bodyClauses(aqlKeywordSort) {
+		attributes := clause.attributes(loop.variable)
+		if len(attributes) == 0 || len(clause.tokens) < 2 || clause.tokens[1].text != loop.variable { //nolint:mnd // keyword and variable
+			return ""
+		}
+
+		return attributes[0].String()
+	}
+
+	return ""
+}
+
+// indexCovers reports whether one of indexes serves attribute, given the
+// filtered attributes: system attributes are always indexed, multi-dimensional
+// indexes serve any of their fields for filtering, and persistent indexes
+// serve a field when the fields before it are filtered.
+func indexCovers(indexes []declaredIndex, attribute string, filtered []string, sorting bool) bool {
+	if slices.Contains(indexedSystemAttributes, attribute) {
+		return true
+	}
+
+	for _, index := range indexes {
+		position := slices.Index(index.Fields, attribute)
+
+		switch {
+		case position < 0:
+			continue
+		case !index.Ordered:
+			if !sorting {
+				return true
+			}
+		case !slices.ContainsFunc(index.Fields[:position], func(field string) bool {
+			return !slices.Contains(filtered, field)
+		}):
+			return true
+		}
+	}
+
+	return false
+}
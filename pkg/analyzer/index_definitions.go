@@ -0,0 +1,277 @@
+package analyzer
+
+import (
+	"go/ast"
+	"go/constant"
+	"go/types"
+	"slices"
+
+	"golang.org/x/tools/go/analysis"
+)
+
+const (
+	msgIndexNoFields          = "%s has no fields"
+	msgIndexDuplicateField    = "%s lists field %q twice"
+	msgIndexEmptyField        = "%s has an empty field name"
+	msgIndexGeoFields         = "EnsureGeoIndex takes one or two fields, got %d"
+	msgIndexTTLFields         = "EnsureTTLIndex takes exactly one field, got %d"
+	msgIndexTTLExpireAfter    = "EnsureTTLIndex expireAfter must not be negative, got %d"
+	msgIndexDeprecatedZKD     = "EnsureZKDIndex is deprecated; use EnsureMDIIndex"
+	methodEnsureGeoIndex      = "EnsureGeoIndex"
+	methodEnsureTTLIndex      = "EnsureTTLIndex"
+	methodEnsureZKDIndex      = "EnsureZKDIndex"
+	methodEnsureMDIPrefixed   = "EnsureMDIPrefixedIndex"
+	methodEnsureInverted      = "EnsureInvertedIndex"
+	collectionIndexesTypeName = "CollectionIndexes"
+	prefixFieldsFieldName     = "PrefixFields"
+	invertedFieldsFieldName   = "Fields"
+	invertedFieldName         = "Name"
+	maxGeoIndexFields         = 2
+	expireAfterArgIndex       = 2
+)
+
+// fieldIndexMethods lists the Collection methods taking the indexed fields as
+// second argument (after the context).
+var fieldIndexMethods = []string{
+	"EnsurePersistentIndex", methodEnsureGeoIndex, methodEnsureTTLIndex, methodEnsureZKDIndex,
+	"EnsureMDIIndex", methodEnsureMDIPrefixed,
+}
+
+// handleIndexDefinitionCall checks the constant fields and options of
+// Ensure*Index calls against the server-side constraints of each index type,
+// and reports the deprecated EnsureZKDIndex.
+func handleIndexDefinitionCall(call *ast.CallExpr, pass *analysis.Pass) {
+	method := arangoMethod(call, pass)
+	if method == nil || methodRecvTypeName(method) != collectionIndexesTypeName {
+		return
+	}
+
+	name := method.Name()
+
+	if name == methodEnsureZKDIndex {
+		pass.Reportf(call.Pos(), msgIndexDeprecatedZKD)
+	}
+
+	if name == methodEnsureInverted {
+		checkInvertedIndexFields(call, pass)
+
+		return
+	}
+
+	if !slices.Contains(fieldIndexMethods, name) || len(call.Args) < 2 { //nolint:mnd // context and fields
+		return
+	}
+
+	fields, known := indexFieldNames(call.Args[1], pass)
+	if !known {
+		return
+	}
+
+	if !checkIndexFields(call.Args[1], name, fields, pass) {
+		return
+	}
+
+	switch name {
+	case methodEnsureGeoIndex:
+		if len(fields) > maxGeoIndexFields {
+			pass.Reportf(call.Args[1].Pos(), msgIndexGeoFields, len(fields))
+		}
+	case methodEnsureTTLIndex:
+		if len(fields) != 1 {
+			pass.Reportf(call.Args[1].Pos(), msgIndexTTLFields, len(fields))
+		}
+
+		checkExpireAfter(call, pass)
+	case methodEnsureMDIPrefixed:
+		checkPrefixFields(call, pass)
+	}
+}
+
+// checkIndexFields reports an empty field list, empty field names and
+// duplicated fields, and returns whether the list passed these checks.
+func checkIndexFields(expr ast.Expr, subject string, fields []string, pass *analysis.Pass) bool {
+	if len(fields) == 0 {
+		pass.Reportf(expr.Pos(), msgIndexNoFields, subject)
+
+		return false
+	}
+
+	for index, field := range fields {
+		if field == "" {
+			pass.Reportf(expr.Pos(), msgIndexEmptyField, subject)
+
+			return false
+		}
+
+		if slices.Contains(fields[:index], field) {
+			pass.Reportf(expr.Pos(), msgIndexDuplicateField, subject, field)
+
+			return false
+		}
+	}
+
+	return true
+}
+
+// checkExpireAfter reports a negative constant expireAfter passed to
+// EnsureTTLIndex. Zero is valid: documents expire as soon as the server time
+// passes the indexed point in time.
+func checkExpireAfter(call *ast.CallExpr, pass *analysis.Pass) {
+	if len(call.Args) <= expireAfterArgIndex {
+		return
+	}
+
+	tv, found := pass.TypesInfo.Types[unwrapParens(call.Args[expireAfterArgIndex])]
+	if !found || tv.Value == nil || tv.Value.Kind() != constant.Int {
+		return
+	}
+
+	if expireAfter, exact := constant.Int64Val(tv.Value); exact && expireAfter < 0 {
+		pass.Reportf(call.Args[expireAfterArgIndex].Pos(), msgIndexTTLExpireAfter, expireAfter)
+	}
+}
+
+// checkPrefixFields checks the PrefixFields of the options passed to
+// EnsureMDIPrefixedIndex.
+func checkPrefixFields(call *ast.CallExpr, pass *analysis.Pass) {
+	if len(call.Args) <= 2 { //nolint:mnd // context, fields and options
+		return
+	}
+
+	value := optionsFieldValue(call.Args[2], prefixFieldsFieldName, pass)
+	if value == nil {
+		return
+	}
+
+	if prefixFields, known := indexFieldNames(value, pass); known {
+		checkIndexFields(value, methodEnsureMDIPrefixed+" "+prefixFieldsFieldName, prefixFields, pass)
+	}
+}
+
+// checkInvertedIndexFields checks the names of the Fields of the options
+// passed to EnsureInvertedIndex, which require at least one field.
+func checkInvertedIndexFields(call *ast.CallExpr, pass *analysis.Pass) {
+	if len(call.Args) < 2 { //nolint:mnd // context and options
+		return
+	}
+
+	literal := nameOptionsLiteral(call.Args[1], pass)
+	if literal == nil {
+		return
+	}
+
+	value := optionsFieldValue(literal, invertedFieldsFieldName, pass)
+	if value == nil {
+		// Options held in a variable may get their fields assigned later.
+		if unwrapAddress(call.Args[1]) == literal && isKeyedLiteral(literal) {
+			pass.Reportf(literal.Pos(), msgIndexNoFields, methodEnsureInverted)
+		}
+
+		return
+	}
+
+	elements, isLiteral := unwrapParens(value).(*ast.CompositeLit)
+	if !isLiteral {
+		return
+	}
+
+	fields := make([]string, 0, len(elements.Elts))
+
+	for _, elt := range elements.Elts {
+		field, isField := unwrapAddress(elt).(*ast.CompositeLit)
+		if !isField || !isKeyedLiteral(field) {
+			return
+		}
+
+		name := optionsFieldValue(field, invertedFieldName, pass)
+		if name == nil {
+			fields = append(fields, "")
+
+			continue
+		}
+
+		fieldName, known := constantString(name, pass)
+		if !known {
+			return
+		}
+
+		fields = append(fields, fieldName)
+	}
+
+	checkIndexFields(value, methodEnsureInverted, fields, pass)
+}
+
+// indexFieldNames returns the constant elements of a []string literal, or of
+// a variable initialized with one and never reassigned.
+func indexFieldNames(expr ast.Expr, pass *analysis.Pass) ([]string, bool) {
+	expr = unwrapParens(expr)
+
+	if id, isIdent := expr.(*ast.Ident); isIdent {
+		obj, isVar := pass.TypesInfo.Uses[id].(*types.Var)
+		if !isVar || isReassigned(obj, pass) {
+			return nil, false
+		}
+
+		expr = localDefinitionValue(obj, pass)
+	}
+
+	if expr == nil {
+		return nil, false
+	}
+
+	literal, isLiteral := unwrapParens(expr).(*ast.CompositeLit)
+	if !isLiteral {
+		return nil, false
+	}
+
+	fields := make([]string, 0, len(literal.Elts))
+
+	for _, elt := range literal.Elts {
+		field, known := constantString(elt, pass)
+		if !known {
+			return nil, false
+		}
+
+		fields = append(fields, field)
+	}
+
+	return fields, true
+}
+
+// optionsFieldValue returns the value set for fieldName by the driver struct
+// literal expr refers to, or nil.
+func optionsFieldValue(expr ast.Expr, fieldName string, pass *analysis.Pass) ast.Expr {
+	literal, isLiteral := expr.(*ast.CompositeLit)
+	if !isLiteral {
+		literal = nameOptionsLiteral(expr, pass)
+	}
+
+	if literal == nil {
+		return nil
+	}
+
+	for _, elt := range literal.Elts {
+		keyValue, isKeyValue := elt.(*ast.KeyValueExpr)
+		if !isKeyValue {
+			continue
+		}
+
+		if key, isIdent := keyValue.Key.(*ast.Ident); isIdent && key.Name == fieldName {
+			return keyValue.Value
+		}
+	}
+
+	return nil
+}
+
+// isKeyedLiteral reports whether the elements of literal are all key-value
+// pairs.
+func isKeyedLiteral(literal *ast.CompositeLit) bool {
+	for _, elt := range literal.Elts {
+		if _, isKeyValue := elt.(*ast.KeyValueExpr); !isKeyValue {
+			return false
+		}
+	}
+
+	return true
+}
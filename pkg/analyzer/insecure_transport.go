@@ -0,0 +1,250 @@
+package analyzer
+
+import (
+	"go/ast"
+	"go/constant"
+	"go/token"
+	"go/types"
+	"net/url"
+	"slices"
+	"strings"
+
+	"golang.org/x/tools/go/analysis"
+)
+
+const (
+	msgInsecureModifier    = "connection.%s disables TLS certificate verification"
+	msgInsecureTransport   = "transport passed to connection.%s sets InsecureSkipVerify; TLS certificates are not verified"
+	msgInsecureEndpoint    = "endpoint %q does not use TLS"
+	msgMalformedEndpoint   = "malformed endpoint %q: expected https://host[:port]"
+	insecureSkipVerifyName = "InsecureSkipVerify"
+	tlsPackagePath         = "crypto/tls"
+	tlsConfigTypeName      = "Config"
+	schemeHTTP             = "http"
+	schemeHTTPS            = "https"
+	// The driver normalizes tcp endpoints to http and ssl endpoints to https.
+	schemeTCP = "tcp"
+	schemeSSL = "ssl"
+)
+
+// insecureModifiers lists the connection modifiers disabling TLS verification.
+var insecureModifiers = []string{"WithHTTPInsecureSkipVerify", "WithHTTP2InsecureSkipVerify"}
+
+// transportModifiers lists the connection functions taking transport modifiers.
+var transportModifiers = []string{"WithHTTPTransport", "WithHTTP2Transport"}
+
+// endpointConstructors lists the connection functions taking endpoint URLs.
+var endpointConstructors = []string{"NewRoundRobinEndpoints", "NewEndpoints", "NewMaglevHashEndpoints"}
+
+// handleInsecureTransportCall reports, outside test files, connection
+// modifiers disabling TLS verification (called or passed as a value), transport
+// modifiers setting InsecureSkipVerify, and constant endpoint URLs that are not
+// https or are malformed.
+func handleInsecureTransportCall(call *ast.CallExpr, pass *analysis.Pass) {
+	if strings.HasSuffix(pass.Fset.File(call.Pos()).Name(), testFileSuffix) {
+		return
+	}
+
+	for _, arg := range call.Args {
+		if modifier := connectionFuncName(arg, pass); slices.Contains(insecureModifiers, modifier) {
+			pass.Reportf(arg.Pos(), msgInsecureModifier, modifier)
+		}
+	}
+
+	name := connectionFuncName(call.Fun, pass)
+
+	switch {
+	case slices.Contains(insecureModifiers, name):
+		pass.Reportf(call.Pos(), msgInsecureModifier, name)
+	case slices.Contains(transportModifiers, name):
+		for _, arg := range call.Args {
+			if body := modifierBody(arg, pass); body != nil && setsInsecureSkipVerify(body, pass) {
+				pass.Reportf(arg.Pos(), msgInsecureTransport, name)
+			}
+		}
+	case slices.Contains(endpointConstructors, name):
+		for _, endpoint := range endpointArguments(call, name, pass) {
+			checkEndpoint(endpoint, pass)
+		}
+	}
+}
+
+// connectionFuncName returns the name of the connection package function
+// expr refers to, or an empty string.
+func connectionFuncName(expr ast.Expr, pass *analysis.Pass) string {
+	var id *ast.Ident
+
+	switch typed := unwrapParens(expr).(type) {
+	case *ast.Ident:
+		id = typed
+	case *ast.SelectorExpr:
+		id = typed.Sel
+	case *ast.IndexExpr:
+		return connectionFuncName(typed.X, pass)
+	default:
+		return ""
+	}
+
+	fn, isFunc := pass.TypesInfo.Uses[id].(*types.Func)
+	if !isFunc || fn.Pkg() == nil || !strings.HasSuffix(fn.Pkg().Path(), connectionPackageSuffix) {
+		return ""
+	}
+
+	return fn.Name()
+}
+
+// modifierBody returns the body of the transport modifier expr: a function
+// literal, a function of the package, or a variable initialized with a
+// function literal.
+func modifierBody(expr ast.Expr, pass *analysis.Pass) *ast.BlockStmt {
+	expr = unwrapParens(expr)
+
+	if funcLit, isFuncLit := expr.(*ast.FuncLit); isFuncLit {
+		return funcLit.Body
+	}
+
+	id, isIdent := expr.(*ast.Ident)
+	if !isIdent {
+		return nil
+	}
+
+	switch obj := pass.TypesInfo.Uses[id].(type) {
+	case *types.Func:
+		if decl := packageFuncDecls(pass)[obj]; decl != nil {
+			return decl.Body
+		}
+	case *types.Var:
+		if funcLit, isFuncLit := unwrapParens(localDefinitionValue(obj, pass)).(*ast.FuncLit); isFuncLit {
+			return funcLit.Body
+		}
+	}
+
+	return nil
+}
+
+// setsInsecureSkipVerify reports whether body builds a tls.Config with
+// InsecureSkipVerify set to true, assigns true to an InsecureSkipVerify field,
+// or uses a tls.Config variable initialized that way.
+func setsInsecureSkipVerify(body *ast.BlockStmt, pass *analysis.Pass) bool {
+	insecure := false
+
+	ast.Inspect(body, func(node ast.Node) bool {
+		switch typed := node.(type) {
+		case *ast.CompositeLit:
+			insecure = insecure || insecureTLSConfigLiteral(typed, pass)
+		case *ast.AssignStmt:
+			for lhsIndex, lhs := range typed.Lhs {
+				sel, isSelector := lhs.(*ast.SelectorExpr)
+				if isSelector && sel.Sel.Name == insecureSkipVerifyName &&
+					isConstantTrue(getRHSForLHS(typed, lhsIndex), pass) {
+					insecure = true
+				}
+			}
+		case *ast.Ident:
+			obj, isVar := pass.TypesInfo.Uses[typed].(*types.Var)
+			declaredOutside := isVar && (obj.Pos() < body.Pos() || obj.Pos() >= body.End())
+			if declaredOutside && isNamedType(deref(obj.Type()), tlsPackagePath, tlsConfigTypeName) {
+				literal, isLiteral := unwrapAddress(localDefinitionValue(obj, pass)).(*ast.CompositeLit)
+				insecure = insecure || (isLiteral && insecureTLSConfigLiteral(literal, pass))
+			}
+		}
+
+		return !insecure
+	})
+
+	return insecure
+}
+
+// insecureTLSConfigLiteral reports whether literal is a tls.Config literal
+// setting InsecureSkipVerify to true.
+func insecureTLSConfigLiteral(literal *ast.CompositeLit, pass *analysis.Pass) bool {
+	if !isNamedType(pass.TypesInfo.TypeOf(literal), tlsPackagePath, tlsConfigTypeName) {
+		return false
+	}
+
+	for _, elt := range literal.Elts {
+		kv, isKeyValue := elt.(*ast.KeyValueExpr)
+		if !isKeyValue {
+			continue
+		}
+
+		if key, isIdent := kv.Key.(*ast.Ident); isIdent && key.Name == insecureSkipVerifyName &&
+			isConstantTrue(kv.Value, pass) {
+			return true
+		}
+	}
+
+	return false
+}
+
+// isConstantTrue reports whether expr is the boolean constant true.
+func isConstantTrue(expr ast.Expr, pass *analysis.Pass) bool {
+	if expr == nil {
+		return false
+	}
+
+	tv, found := pass.TypesInfo.Types[unwrapParens(expr)]
+
+	return found && tv.Value != nil && tv.Value.Kind() == constant.Bool && constant.BoolVal(tv.Value)
+}
+
+// unwrapAddress strips parentheses and an address-of operator from expr.
+func unwrapAddress(expr ast.Expr) ast.Expr {
+	expr = unwrapParens(expr)
+	if unary, isUnary := expr.(*ast.UnaryExpr); isUnary && unary.Op == token.AND {
+		return unwrapParens(unary.X)
+	}
+
+	return expr
+}
+
+// endpointArguments returns the endpoint URL expressions passed to an
+// endpoint constructor: the variadic arguments of NewEndpoints, or the
+// elements of the []string literal (possibly through a local variable) of the
+// other constructors.
+func endpointArguments(call *ast.CallExpr, name string, pass *analysis.Pass) []ast.Expr {
+	if name == "NewEndpoints" {
+		if call.Ellipsis.IsValid() {
+			return nil
+		}
+
+		return call.Args
+	}
+
+	if len(call.Args) == 0 {
+		return nil
+	}
+
+	list := unwrapParens(call.Args[0])
+	if id, isIdent := list.(*ast.Ident); isIdent {
+		if obj, isVar := pass.TypesInfo.Uses[id].(*types.Var); isVar && !isReassigned(obj, pass) {
+			list = unwrapParens(localDefinitionValue(obj, pass))
+		}
+	}
+
+	literal, isLiteral := list.(*ast.CompositeLit)
+	if !isLiteral {
+		return nil
+	}
+
+	return literal.Elts
+}
+
+// checkEndpoint reports a constant endpoint URL that is not https (or ssl) or
+// is malformed.
+func checkEndpoint(endpoint ast.Expr, pass *analysis.Pass) {
+	value, known := constantString(endpoint, pass)
+	if !known {
+		return
+	}
+
+	parsed, err := url.Parse(value)
+
+	switch {
+	case err != nil || parsed.Host == "" ||
+		!slices.Contains([]string{schemeHTTP, schemeHTTPS, schemeTCP, schemeSSL}, parsed.Scheme):
+		pass.Reportf(endpoint.Pos(), msgMalformedEndpoint, value)
+	case parsed.Scheme == schemeHTTP || parsed.Scheme == schemeTCP:
+		pass.Reportf(endpoint.Pos(), msgInsecureEndpoint, value)
+	}
+}
@@ -0,0 +1,141 @@
+package analyzer
+
+import (
+	"go/ast"
+	"go/token"
+	"go/types"
+
+	"golang.org/x/tools/go/analysis"
+)
+
+const (
+	msgLookupInLoop    = "%s called in a loop with a loop-invariant name; resolve it once before the loop"
+	msgLookupInHandler = "%s called in an HTTP handler with a constant name; resolve it once and reuse the handle"
+	httpPackagePath    = "net/http"
+	lookupNameArgIndex = 1
+)
+
+// lookupMethods lists the handle lookups doing a server round trip, keyed by
+// method name, with the interface declaring them.
+var lookupMethods = map[string]string{
+	"GetCollection": "DatabaseCollection",
+	"Collection":    "DatabaseCollection",
+	"GetDatabase":   "ClientDatabase",
+}
+
+// handleLookupHoistCall reports database and collection lookups that should
+// be hoisted out of hot paths: inside loops when the name does not depend on
+// the iteration, and inside HTTP handlers when the name is a constant.
+func handleLookupHoistCall(call *ast.CallExpr, pass *analysis.Pass, stack []ast.Node) {
+	method := arangoMethod(call, pass)
+	if method == nil || lookupMethods[method.Name()] == "" ||
+		lookupMethods[method.Name()] != methodRecvTypeName(method) || len(call.Args) <= lookupNameArgIndex {
+		return
+	}
+
+	nameArg := call.Args[lookupNameArgIndex]
+
+	for _, loop := range enclosingLoops(stack) {
+		if within(call, loopBody(loop)) && !referencesAny([]ast.Expr{nameArg}, loopVariant(loop, pass), pass) {
+			pass.Reportf(call.Pos(), msgLookupInLoop, method.Name())
+
+			return
+		}
+	}
+
+	if isConstantExpr(nameArg, pass) && isHTTPHandlerFunc(enclosingFunc(stack), pass) {
+		pass.Reportf(call.Pos(), msgLookupInHandler, method.Name())
+	}
+}
+
+// loopVariant returns the variables whose value may change from one iteration
+// of loop to the next: the loop variables and the variables derived from them,
+// along with every variable declared, assigned, incremented or having its
+// address taken inside the loop body.
+func loopVariant(loop ast.Stmt, pass *analysis.Pass) map[types.Object]bool {
+	variant := loopTaint(loop, pass)
+
+	addRoot := func(expr ast.Expr) {
+		if id := rootIdent(expr); id != nil {
+			if obj := pass.TypesInfo.ObjectOf(id); obj != nil {
+				variant[obj] = true
+			}
+		}
+	}
+
+	ast.Inspect(loopBody(loop), func(node ast.Node) bool {
+		switch typed := node.(type) {
+		case *ast.AssignStmt:
+			for _, lhs := range typed.Lhs {
+				addRoot(lhs)
+			}
+		case *ast.ValueSpec:
+			for _, name := range typed.Names {
+				addRoot(name)
+			}
+		case *ast.RangeStmt:
+			addRoot(typed.Key)
+			addRoot(typed.Value)
+		case *ast.IncDecStmt:
+			addRoot(typed.X)
+		case *ast.UnaryExpr:
+			if typed.Op == token.AND {
+				addRoot(typed.X)
+			}
+		}
+
+		return true
+	})
+
+	return variant
+}
+
+// isConstantExpr reports whether expr is a compile-time constant.
+func isConstantExpr(expr ast.Expr, pass *analysis.Pass) bool {
+	typeAndValue, known := pass.TypesInfo.Types[expr]
+
+	return known && typeAndValue.Value != nil
+}
+
+// isHTTPHandlerFunc reports whether fn has the func(http.ResponseWriter,
+// *http.Request) signature, which covers http.HandlerFunc values and
+// http.Handler ServeHTTP methods.
+func isHTTPHandlerFunc(fn ast.Node, pass *analysis.Pass) bool {
+	var fnType types.Type
+
+	switch typed := fn.(type) {
+	case *ast.FuncDecl:
+		if obj := pass.TypesInfo.Defs[typed.Name]; obj != nil {
+			fnType = obj.Type()
+		}
+	case *ast.FuncLit:
+		fnType = pass.TypesInfo.TypeOf(typed)
+	}
+
+	sig, isSignature := fnType.(*types.Signature)
+	if !isSignature || sig.Params().Len() != 2 { //nolint:mnd // writer and request
+		return false
+	}
+
+	return isNamedType(sig.Params().At(0).Type(), httpPackagePath, "ResponseWriter") &&
+		isNamedType(deref(sig.Params().At(1).Type()), httpPackagePath, "Request")
+}
+
+// isNamedType reports whether t is the named type pkgPath.name.
+func isNamedType(t types.Type, pkgPath, name string) bool {
+	named, isNamed := t.(*types.Named)
+	if !isNamed || named.Obj().Pkg() == nil {
+		return false
+	}
+
+	return named.Obj().Pkg().Path() == pkgPath && named.Obj().Name() == name
+}
+
+// deref returns the element type of a pointer, or t itself.
+func deref(t types.Type) types.Type {
+	if ptr, isPointer := t.(*types.Pointer); isPointer {
+		return ptr.Elem()
+	}
+
+	return t
+}
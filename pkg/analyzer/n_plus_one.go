@@ -0,0 +1,162 @@
+package analyzer
+
+import (
+	"go/ast"
+	"go/types"
+	"strings"
+
+	"golang.org/x/tools/go/analysis"
+)
+
+const (
+	msgNPlusOneQuery    = "%s called in a loop with per-iteration arguments; use an AQL FOR over a bind array"
+	msgNPlusOneDocument = "%s called in a loop with per-iteration arguments; use %s"
+	maxTaintPasses      = 4
+	// documentMethodsPrefix prefixes the CollectionDocument* interfaces
+	// declaring the single-document methods.
+	documentMethodsPrefix = "CollectionDocument"
+)
+
+// nPlusOneDocumentMethods maps single-document methods to their batch counterpart.
+var nPlusOneDocumentMethods = map[string]string{
+	"ReadDocument":               "ReadDocuments",
+	"ReadDocumentWithOptions":    "ReadDocumentsWithOptions",
+	"CreateDocument":             "CreateDocuments",
+	"CreateDocumentWithOptions":  "CreateDocumentsWithOptions",
+	"UpdateDocument":             "UpdateDocuments",
+	"UpdateDocumentWithOptions":  "UpdateDocumentsWithOptions",
+	"ReplaceDocument":            "ReplaceDocuments",
+	"ReplaceDocumentWithOptions": "ReplaceDocumentsWithOptions",
+	"DeleteDocument":             "DeleteDocuments",
+	"DeleteDocumentWithOptions":  "DeleteDocumentsWithOptions",
+}
+
+// handleNPlusOneCall reports queries and single-document calls executed once
+// per loop iteration with arguments derived from the loop variables, which
+// turns into one server round trip per element.
+func handleNPlusOneCall(call *ast.CallExpr, pass *analysis.Pass, stack []ast.Node) {
+	methodName, batchName := nPlusOneMethod(call, pass)
+	if methodName == "" || len(call.Args) < 2 {
+		return
+	}
+
+	for _, loop := range enclosingLoops(stack) {
+		if !within(call, loopBody(loop)) {
+			continue
+		}
+
+		if !referencesAny(call.Args[1:], loopTaint(loop, pass), pass) {
+			continue
+		}
+
+		if batchName == "" {
+			pass.Reportf(call.Pos(), msgNPlusOneQuery, methodName)
+		} else {
+			pass.Reportf(call.Pos(), msgNPlusOneDocument, methodName, batchName)
+		}
+
+		return
+	}
+}
+
+// nPlusOneMethod returns the name of the query or single-document method
+// invoked by call, and the batch method to suggest (empty for queries).
+func nPlusOneMethod(call *ast.CallExpr, pass *analysis.Pass) (methodName, batchName string) {
+	if name, _ := identifyQueryMethod(call, pass); name == methodQuery || name == methodQueryBatch {
+		return name, ""
+	}
+
+	method := arangoMethod(call, pass)
+	if method == nil || !strings.HasPrefix(methodRecvTypeName(method), documentMethodsPrefix) {
+		return "", ""
+	}
+
+	batchName, isSingle := nPlusOneDocumentMethods[method.Name()]
+	if !isSingle {
+		return "", ""
+	}
+
+	return method.Name(), batchName
+}
+
+// loopTaint returns the loop variables of loop along with the local variables
+// of its body whose value is derived from them.
+func loopTaint(loop ast.Stmt, pass *analysis.Pass) map[types.Object]bool {
+	tainted := make(map[types.Object]bool)
+
+	addIdent := func(expr ast.Expr) {
+		if id, isIdent := expr.(*ast.Ident); isIdent {
+			if obj := pass.TypesInfo.ObjectOf(id); obj != nil {
+				tainted[obj] = true
+			}
+		}
+	}
+
+	switch typed := loop.(type) {
+	case *ast.RangeStmt:
+		addIdent(typed.Key)
+		addIdent(typed.Value)
+	case *ast.ForStmt:
+		if assign, isAssign := typed.Init.(*ast.AssignStmt); isAssign {
+			for _, lhs := range assign.Lhs {
+				addIdent(lhs)
+			}
+		}
+	}
+
+	// Propagate through assignments until a fixpoint (bounded for safety).
+	for range maxTaintPasses {
+		before := len(tainted)
+
+		ast.Inspect(loopBody(loop), func(node ast.Node) bool {
+			switch typed := node.(type) {
+			case *ast.AssignStmt:
+				if referencesAny(typed.Rhs, tainted, pass) {
+					for _, lhs := range typed.Lhs {
+						addIdent(lhs)
+					}
+				}
+			case *ast.ValueSpec:
+				if referencesAny(typed.Values, tainted, pass) {
+					for _, name := range typed.Names {
+						addIdent(name)
+					}
+				}
+			case *ast.RangeStmt:
+				if referencesAny([]ast.Expr{typed.X}, tainted, pass) {
+					addIdent(typed.Key)
+					addIdent(typed.Value)
+				}
+			}
+
+			return true
+		})
+
+		if len(tainted) == before {
+			break
+		}
+	}
+
+	return tainted
+}
+
+// referencesAny reports whether any of exprs references one of objs.
+func referencesAny(exprs []ast.Expr, objs map[types.Object]bool, pass *analysis.Pass) bool {
+	found := false
+
+	for _, expr := range exprs {
+		ast.Inspect(expr, func(node ast.Node) bool {
+			if id, isIdent := node.(*ast.Ident); isIdent && objs[pass.TypesInfo.ObjectOf(id)] {
+				found = true
+			}
+
+			return !found
+		})
+
+		if found {
+			return true
+		}
+	}
+
+	return false
+}
@@ -0,0 +1,280 @@
+package analyzer
+
+import (
+	"fmt"
+	"go/ast"
+	"go/types"
+	"strings"
+
+	"golang.org/x/tools/go/analysis"
+)
+
+const (
+	msgInvalidName          = "invalid %s name %q: %s"
+	reasonEmptyName         = "must not be empty"
+	reasonNameTooLong       = "must be at most %d bytes long"
+	reasonNameStart         = "must start with a letter"
+	reasonSystemName        = "must start with a letter; only system collections start with an underscore"
+	reasonNameCharacter     = "contains %q; only letters, digits, underscores and dashes are allowed"
+	systemNamePrefix        = "_"
+	systemDatabaseName      = "_system"
+	isSystemFieldName       = "IsSystem"
+	nameFieldSeparator      = "."
+	maxCollectionNameLength = 256
+	maxDatabaseNameLength   = 64
+	maxViewNameLength       = 256
+	maxAnalyzerNameLength   = 254
+	maxGraphNameLength      = 256
+)
+
+// nameKind describes an ArangoDB object kind and its naming rules.
+type nameKind struct {
+	label     string
+	maxLength int
+}
+
+var (
+	collectionName = nameKind{label: "collection", maxLength: maxCollectionNameLength}
+	databaseName   = nameKind{label: "database", maxLength: maxDatabaseNameLength}
+	viewName       = nameKind{label: "view", maxLength: maxViewNameLength}
+	analyzerName   = nameKind{label: "analyzer", maxLength: maxAnalyzerNameLength}
+	graphName      = nameKind{label: "graph", maxLength: maxGraphNameLength}
+)
+
+// nameArguments maps the driver methods taking an object name as second
+// argument (after the context) to the kind of that object.
+var nameArguments = map[string]nameKind{
+	"CreateCollection":            collectionName,
+	"CreateCollectionWithOptions": collectionName,
+	"GetCollection":               collectionName,
+	"Collection":                  collectionName,
+	"CollectionExists":            collectionName,
+	"CreateVertexCollection":      collectionName,
+	"CreateEdgeDefinition":        collectionName,
+	"CreateDatabase":              databaseName,
+	"GetDatabase":                 databaseName,
+	"DatabaseExists":              databaseName,
+	"CreateArangoSearchView":      viewName,
+	"CreateArangoSearchAliasView": viewName,
+	"View":                        viewName,
+	"ViewExists":                  viewName,
+	"Analyzer":                    analyzerName,
+	"CreateGraph":                 graphName,
+	"Graph":                       graphName,
+	"GraphExists":                 graphName,
+}
+
+// nameFields maps the driver struct fields ("Type.Field") holding object
+// names, as a string or a []string, to the kind of those objects.
+var nameFields = map[string]nameKind{
+	"AnalyzerDefinition.Name":                analyzerName,
+	"TransactionCollections.Read":            collectionName,
+	"TransactionCollections.Write":           collectionName,
+	"TransactionCollections.Exclusive":       collectionName,
+	"GraphDefinition.OrphanCollections":      collectionName,
+	"EdgeDefinition.Collection":              collectionName,
+	"EdgeDefinition.From":                    collectionName,
+	"EdgeDefinition.To":                      collectionName,
+	"CreateEdgeDefinitionOptions.Satellites": collectionName,
+}
+
+// collectionListArguments maps the driver methods taking lists of collection
+// names, as []string arguments, to the indexes of those arguments.
+var collectionListArguments = map[string][]int{
+	"CreateEdgeDefinition":  {2, 3},
+	"ReplaceEdgeDefinition": {2, 3},
+}
+
+// collectionCreators lists the methods creating a collection, whose properties
+// decide whether a leading underscore is allowed.
+var collectionCreators = []string{"CreateCollection", "CreateCollectionWithOptions"}
+
+// handleNamingRulesCall reports constant collection, database, view, analyzer
+// and graph names passed to driver methods, directly or through driver option
+// literals, that break ArangoDB's traditional naming rules.
+func handleNamingRulesCall(call *ast.CallExpr, pass *analysis.Pass) {
+	method := arangoMethod(call, pass)
+	if method == nil {
+		return
+	}
+
+	if kind, found := nameArguments[method.Name()]; found && len(call.Args) > 1 {
+		checkName(call.Args[1], kind, nameAllowsSystemPrefix(call, method, kind, pass), pass)
+	}
+
+	for _, index := range collectionListArguments[method.Name()] {
+		if index >= len(call.Args) {
+			continue
+		}
+
+		if elements, isLiteral := unwrapParens(call.Args[index]).(*ast.CompositeLit); isLiteral {
+			for _, value := range elements.Elts {
+				checkName(value, collectionName, true, pass)
+			}
+		}
+	}
+
+	for _, arg := range call.Args {
+		literal := nameOptionsLiteral(arg, pass)
+		if literal == nil {
+			continue
+		}
+
+		ast.Inspect(literal, func(node ast.Node) bool {
+			if nested, isLiteral := node.(*ast.CompositeLit); isLiteral {
+				checkNameFields(nested, pass)
+			}
+
+			return true
+		})
+	}
+}
+
+// nameAllowsSystemPrefix reports whether the name argument of call may start
+// with an underscore: references to existing collections (which then are
+// system collections), the _system database, and collections created with
+// IsSystem set to true.
+func nameAllowsSystemPrefix(call *ast.CallExpr, method *types.Func, kind nameKind, pass *analysis.Pass) bool {
+	switch kind {
+	case collectionName:
+		for _, creator := range collectionCreators {
+			if method.Name() == creator {
+				return len(call.Args) > 2 && createsSystemCollection(call.Args[2], pass)
+			}
+		}
+
+		return true
+	case databaseName:
+		value, known := constantString(call.Args[1], pass)
+
+		return known && value == systemDatabaseName
+	default:
+		return false
+	}
+}
+
+// createsSystemCollection reports whether props is a collection properties
+// literal setting IsSystem to true.
+func createsSystemCollection(props ast.Expr, pass *analysis.Pass) bool {
+	literal := nameOptionsLiteral(props, pass)
+	if literal == nil {
+		return false
+	}
+
+	for _, elt := range literal.Elts {
+		keyValue, isKeyValue := elt.(*ast.KeyValueExpr)
+		if !isKeyValue {
+			continue
+		}
+
+		if key, isIdent := keyValue.Key.(*ast.Ident); isIdent && key.Name == isSystemFieldName &&
+			isConstantTrue(keyValue.Value, pass) {
+			return true
+		}
+	}
+
+	return false
+}
+
+// nameOptionsLiteral returns the driver struct literal expr refers to: a
+// literal, its address, or a variable initialized with one and never
+// reassigned.
+func nameOptionsLiteral(expr ast.Expr, pass *analysis.Pass) *ast.CompositeLit {
+	expr = unwrapAddress(expr)
+
+	if id, isIdent := expr.(*ast.Ident); isIdent {
+		obj, isVar := pass.TypesInfo.Uses[id].(*types.Var)
+		if !isVar || isReassigned(obj, pass) {
+			return nil
+		}
+
+		expr = unwrapAddress(localDefinitionValue(obj, pass))
+	}
+
+	literal, isLiteral := expr.(*ast.CompositeLit)
+	if !isLiteral || arangoTypeName(deref(pass.TypesInfo.TypeOf(literal))) == "" {
+		return nil
+	}
+
+	return literal
+}
+
+// checkNameFields checks the names set in the nameFields of the driver struct
+// literal, as a string or a []string literal.
+func checkNameFields(literal *ast.CompositeLit, pass *analysis.Pass) {
+	typeName := arangoTypeName(deref(pass.TypesInfo.TypeOf(literal)))
+	if typeName == "" {
+		return
+	}
+
+	for _, elt := range literal.Elts {
+		keyValue, isKeyValue := elt.(*ast.KeyValueExpr)
+		if !isKeyValue {
+			continue
+		}
+
+		key, isIdent := keyValue.Key.(*ast.Ident)
+		if !isIdent {
+			continue
+		}
+
+		kind, found := nameFields[typeName+nameFieldSeparator+key.Name]
+		if !found {
+			continue
+		}
+
+		values := []ast.Expr{keyValue.Value}
+		if elements, isLiteral := unwrapParens(keyValue.Value).(*ast.CompositeLit); isLiteral {
+			values = elements.Elts
+		}
+
+		for _, value := range values {
+			checkName(value, kind, kind == collectionName, pass)
+		}
+	}
+}
+
+// checkName reports expr when it is a constant name breaking the naming
+// rules of kind.
+func checkName(expr ast.Expr, kind nameKind, allowSystemPrefix bool, pass *analysis.Pass) {
+	value, known := constantString(expr, pass)
+	if !known {
+		return
+	}
+
+	if reason := invalidNameReason(value, kind, allowSystemPrefix); reason != "" {
+		pass.Reportf(expr.Pos(), msgInvalidName, kind.label, value, reason)
+	}
+}
+
+// invalidNameReason explains why name breaks ArangoDB's traditional naming
+// rules for kind, or returns an empty string when it is valid.
+func invalidNameReason(name string, kind nameKind, allowSystemPrefix bool) string {
+	switch {
+	case name == "":
+		return reasonEmptyName
+	case len(name) > kind.maxLength:
+		return fmt.Sprintf(reasonNameTooLong, kind.maxLength)
+	case strings.HasPrefix(name, systemNamePrefix) && !allowSystemPrefix:
+		if kind == collectionName {
+			return reasonSystemName
+		}
+
+		return reasonNameStart
+	case !isASCIILetter(rune(name[0])) && !strings.HasPrefix(name, systemNamePrefix):
+		return reasonNameStart
+	}
+
+	for _, char := range name {
+		if !isASCIILetter(char) && (char < '0' || char > '9') && char != '_' && char != '-' {
+			return fmt.Sprintf(reasonNameCharacter, char)
+		}
+	}
+
+	return ""
+}
+
+// isASCIILetter reports whether char is an ASCII letter.
+func isASCIILetter(char rune) bool {
+	return (char >= 'a' && char <= 'z') || (char >= 'A' && char <= 'Z')
+}
@@ -0,0 +1,282 @@
+package analyzer
+
+import (
+	"fmt"
+	"go/ast"
+	"go/constant"
+	"go/token"
+	"go/types"
+	"slices"
+	"strings"
+
+	"golang.org/x/tools/go/analysis"
+)
+
+const (
+	msgNestedTransaction       = "nested transaction started while transaction %s is open; %s"
+	msgNestedTransactionHelper = "%s starts a nested transaction while transaction %s is open; %s"
+	overlapWrite               = "write collections overlap: %s"
+	overlapNone                = "no overlapping write collections"
+	overlapUnknown             = "collection overlap unknown"
+	colsFieldRead              = "Read"
+	colsFieldWrite             = "Write"
+	colsFieldExclusive         = "Exclusive"
+)
+
+// transactionCollections is the static content of a TransactionCollections value.
+type transactionCollections struct {
+	read, write []string
+}
+
+// handleNestedTransactionCall reports transactions started while another
+// transaction of the same function is open, directly or through functions of
+// the same package. Two transactions writing the same collections deadlock, so
+// the report tells whether their Write/Exclusive collections overlap.
+func handleNestedTransactionCall(call *ast.CallExpr, pass *analysis.Pass, stack []ast.Node) {
+	span := beginTransactionSpan(call, pass, stack)
+	if span == nil {
+		span = withTransactionSpan(call, pass)
+	}
+
+	// Without an explicit Commit/Abort we cannot tell how long the transaction stays open.
+	if span == nil || !span.closed {
+		return
+	}
+
+	outerCols, outerKnown := resolveTransactionCollections(span.cols, pass)
+	decls := packageFuncDecls(pass)
+
+	ast.Inspect(span.body, func(node ast.Node) bool {
+		if node == nil || !span.overlaps(node) {
+			return false
+		}
+
+		switch typed := node.(type) {
+		case *ast.FuncLit, *ast.GoStmt:
+			return false
+		case *ast.CallExpr:
+			if !span.contains(typed.Pos()) {
+				return true
+			}
+
+			if isTransactionStart(typed, pass) {
+				innerCols, innerKnown := resolveTransactionCollections(typed.Args[1], pass)
+				overlap := describeOverlap(outerCols, innerCols, outerKnown && innerKnown)
+				pass.Reportf(typed.Pos(), msgNestedTransaction, span.name, overlap)
+
+				return true
+			}
+
+			callee := calledFunc(typed, pass)
+			if inner := findTransactionStart(callee, decls, pass, nil); inner != nil {
+				innerCols, innerKnown := resolveTransactionCollections(inner.Args[1], pass)
+				overlap := describeOverlap(outerCols, innerCols, outerKnown && innerKnown)
+				pass.Reportf(typed.Pos(), msgNestedTransactionHelper, callee.Name(), span.name, overlap)
+			}
+		}
+
+		return true
+	})
+}
+
+// isTransactionStart reports whether call starts a streaming transaction.
+func isTransactionStart(call *ast.CallExpr, pass *analysis.Pass) bool {
+	if isBeginTransaction(call, pass) {
+		return true
+	}
+
+	method := arangoMethod(call, pass)
+
+	return method != nil && method.Name() == methodWithTransaction && len(call.Args) > 1
+}
+
+// findTransactionStart returns the first transaction start found in the body of
+// the package function fn or in the package functions it calls.
+func findTransactionStart(
+	fn *types.Func,
+	decls map[*types.Func]*ast.FuncDecl,
+	pass *analysis.Pass,
+	visited []*types.Func,
+) *ast.CallExpr {
+	decl := decls[fn]
+	if decl == nil || decl.Body == nil || slices.Contains(visited, fn) {
+		return nil
+	}
+
+	visited = append(visited, fn)
+
+	var found *ast.CallExpr
+
+	ast.Inspect(decl.Body, func(node ast.Node) bool {
+		if found != nil {
+			return false
+		}
+
+		call, isCall := node.(*ast.CallExpr)
+		if !isCall {
+			return true
+		}
+
+		if isTransactionStart(call, pass) {
+			found = call
+
+			return false
+		}
+
+		found = findTransactionStart(calledFunc(call, pass), decls, pass, visited)
+
+		return true
+	})
+
+	return found
+}
+
+// resolveTransactionCollections returns the collections of a TransactionCollections
+// expression: a composite literal, or a local variable initialized with one.
+func resolveTransactionCollections(expr ast.Expr, pass *analysis.Pass) (transactionCollections, bool) {
+	expr = unwrapParens(expr)
+
+	if id, isIdent := expr.(*ast.Ident); isIdent {
+		expr = localDefinitionValue(pass.TypesInfo.ObjectOf(id), pass)
+	}
+
+	literal, isLiteral := unwrapParens(expr).(*ast.CompositeLit)
+	if !isLiteral {
+		return transactionCollections{}, false
+	}
+
+	var cols transactionCollections
+
+	for _, elt := range literal.Elts {
+		keyValue, isKeyValue := elt.(*ast.KeyValueExpr)
+		if !isKeyValue {
+			return transactionCollections{}, false
+		}
+
+		key, isKeyIdent := keyValue.Key.(*ast.Ident)
+		if !isKeyIdent {
+			return transactionCollections{}, false
+		}
+
+		names, known := constantStrings(keyValue.Value, pass)
+		if !known {
+			return transactionCollections{}, false
+		}
+
+		switch key.Name {
+		case colsFieldRead:
+			cols.read = append(cols.read, names...)
+		case colsFieldWrite, colsFieldExclusive:
+			cols.write = append(cols.write, names...)
+		}
+	}
+
+	return cols, true
+}
+
+// localDefinitionValue returns the expression the variable obj is initialized
+// with (var declaration or short variable declaration), or nil.
+func localDefinitionValue(obj types.Object, pass *analysis.Pass) ast.Expr {
+	file := fileOf(obj, pass)
+	if file == nil {
+		return nil
+	}
+
+	var value ast.Expr
+
+	ast.Inspect(file, func(node ast.Node) bool {
+		if value != nil {
+			return false
+		}
+
+		switch typed := node.(type) {
+		case *ast.AssignStmt:
+			for lhsIndex, lhs := range typed.Lhs {
+				if id, isIdent := lhs.(*ast.Ident); isIdent && pass.TypesInfo.Defs[id] == obj {
+					value = getRHSForLHS(typed, lhsIndex)
+				}
+			}
+		case *ast.ValueSpec:
+			for nameIndex, name := range typed.Names {
+				if pass.TypesInfo.Defs[name] == obj {
+					value = getRHSValueForIndex(typed, nameIndex)
+				}
+			}
+		}
+
+		return true
+	})
+
+	return value
+}
+
+// fileOf returns the file of the package declaring obj, or nil.
+func fileOf(obj types.Object, pass *analysis.Pass) *ast.File {
+	if obj == nil {
+		return nil
+	}
+
+	return fileAt(obj.Pos(), pass)
+}
+
+// fileAt returns the file of the package containing pos, or nil.
+func fileAt(pos token.Pos, pass *analysis.Pass) *ast.File {
+	for _, file := range pass.Files {
+		if file.FileStart <= pos && pos < file.FileEnd {
+			return file
+		}
+	}
+
+	return nil
+}
+
+// constantStrings returns the elements of a []string composite literal made of
+// constant strings.
+func constantStrings(expr ast.Expr, pass *analysis.Pass) ([]string, bool) {
+	literal, isLiteral := unwrapParens(expr).(*ast.CompositeLit)
+	if !isLiteral {
+		return nil, false
+	}
+
+	values := make([]string, 0, len(literal.Elts))
+
+	for _, elt := range literal.Elts {
+		typeAndValue := pass.TypesInfo.Types[elt]
+		if typeAndValue.Value == nil || typeAndValue.Value.Kind() != constant.String {
+			return nil, false
+		}
+
+		values = append(values, constant.StringVal(typeAndValue.Value))
+	}
+
+	return values, true
+}
+
+// describeOverlap explains whether two transactions write to common collections.
+func describeOverlap(outer, inner transactionCollections, known bool) string {
+	if !known {
+		return overlapUnknown
+	}
+
+	var overlap []string
+
+	for _, name := range outer.write {
+		if slices.Contains(inner.write, name) || slices.Contains(inner.read, name) {
+			overlap = append(overlap, name)
+		}
+	}
+
+	for _, name := range inner.write {
+		if slices.Contains(outer.read, name) {
+			overlap = append(overlap, name)
+		}
+	}
+
+	if len(overlap) == 0 {
+		return overlapNone
+	}
+
+	slices.Sort(overlap)
+
+	return fmt.Sprintf(overlapWrite, strings.Join(slices.Compact(overlap), ", "))
+}
@@ -0,0 +1,218 @@
+package analyzer
+
+import (
+	"go/ast"
+	"go/token"
+	"go/types"
+	"slices"
+	"strings"
+
+	"golang.org/x/tools/go/analysis"
+)
+
+const (
+	msgClientInLoop         = "%s called in a loop; create the client once and reuse it"
+	msgClientInHandler      = "%s called in an HTTP handler; create the client once and reuse it"
+	msgClientReachable      = "%s called in %s, reachable from %s; create the client once and reuse it"
+	rootKindLoop            = "a loop"
+	rootKindHandler         = "an HTTP handler"
+	connectionPackageSuffix = "github.com/arangodb/go-driver/v2/connection"
+	funcNewClient           = "NewClient"
+	funcNewHTTPConnection   = "NewHttpConnection"
+	funcNewHTTP2Connection  = "NewHttp2Connection"
+	funcNewPool             = "NewPool"
+)
+
+// clientScan holds the state shared while looking for client constructions
+// reachable from loops and HTTP handlers.
+type clientScan struct {
+	pass     *analysis.Pass
+	decls    map[*types.Func]*ast.FuncDecl
+	reported map[token.Pos]bool
+}
+
+// reportPerRequestClients reports driver clients and connections created per
+// request: constructions inside HTTP handlers, inside package functions
+// transitively called from them, and inside loops of either. Clients built at
+// package init, in main or in constructors not reachable from a handler are
+// fine, including when they loop over configured endpoints.
+func reportPerRequestClients(pass *analysis.Pass) {
+	scan := &clientScan{
+		pass:     pass,
+		decls:    packageFuncDecls(pass),
+		reported: make(map[token.Pos]bool),
+	}
+
+	var handlers []*ast.BlockStmt
+
+	for _, file := range pass.Files {
+		ast.Inspect(file, func(node ast.Node) bool {
+			switch typed := node.(type) {
+			case *ast.FuncDecl:
+				if typed.Body != nil && isHTTPHandlerFunc(typed, pass) {
+					handlers = append(handlers, typed.Body)
+				}
+			case *ast.FuncLit:
+				if isHTTPHandlerFunc(typed, pass) {
+					handlers = append(handlers, typed.Body)
+				}
+			}
+
+			return true
+		})
+	}
+
+	// Loops first, so constructions in loops inside handlers get the more specific message.
+	for _, body := range scan.reachableBodies(handlers) {
+		ast.Inspect(body, func(node ast.Node) bool {
+			switch typed := node.(type) {
+			case *ast.ForStmt:
+				scan.check(typed.Body, msgClientInLoop, rootKindLoop)
+			case *ast.RangeStmt:
+				scan.check(typed.Body, msgClientInLoop, rootKindLoop)
+			}
+
+			return true
+		})
+	}
+
+	for _, body := range handlers {
+		scan.check(body, msgClientInHandler, rootKindHandler)
+	}
+}
+
+// reachableBodies returns roots along with the bodies of the package functions
+// they transitively call.
+func (s *clientScan) reachableBodies(roots []*ast.BlockStmt) []*ast.BlockStmt {
+	bodies := slices.Clone(roots)
+	visited := make(map[*types.Func]bool)
+
+	for index := 0; index < len(bodies); index++ {
+		ast.Inspect(bodies[index], func(node ast.Node) bool {
+			call, isCall := node.(*ast.CallExpr)
+			if !isCall {
+				return true
+			}
+
+			callee := calledFunc(call, s.pass)
+			if decl := s.decls[callee]; decl != nil && decl.Body != nil && !visited[callee] {
+				visited[callee] = true
+				bodies = append(bodies, decl.Body)
+			}
+
+			return true
+		})
+	}
+
+	return bodies
+}
+
+// check reports constructions found in root, and in the package functions it
+// transitively calls.
+func (s *clientScan) check(root ast.Node, directMsg, rootKind string) {
+	visited := make(map[*types.Func]bool)
+
+	var walk func(node ast.Node, via string)
+
+	walk = func(node ast.Node, via string) {
+		ast.Inspect(node, func(child ast.Node) bool {
+			call, isCall := child.(*ast.CallExpr)
+			if !isCall {
+				return true
+			}
+
+			if name := clientConstructor(call, s.pass); name != "" {
+				s.report(call, name, directMsg, via, rootKind)
+			}
+
+			callee := calledFunc(call, s.pass)
+			if decl := s.decls[callee]; decl != nil && decl.Body != nil && !visited[callee] {
+				visited[callee] = true
+				walk(decl.Body, callee.Name())
+			}
+
+			return true
+		})
+	}
+
+	walk(root, "")
+}
+
+// report emits a single diagnostic per construction site.
+func (s *clientScan) report(call *ast.CallExpr, name, directMsg, via, rootKind string) {
+	if s.reported[call.Pos()] {
+		return
+	}
+
+	s.reported[call.Pos()] = true
+
+	if via == "" {
+		s.pass.Reportf(call.Pos(), directMsg, name)
+	} else {
+		s.pass.Reportf(call.Pos(), msgClientReachable, name, via, rootKind)
+	}
+}
+
+// packageFuncDecls indexes the function and method declarations of the package.
+func packageFuncDecls(pass *analysis.Pass) map[*types.Func]*ast.FuncDecl {
+	decls := make(map[*types.Func]*ast.FuncDecl)
+
+	for _, file := range pass.Files {
+		for _, decl := range file.Decls {
+			funcDecl, isFuncDecl := decl.(*ast.FuncDecl)
+			if !isFuncDecl {
+				continue
+			}
+
+			if fn, isFunc := pass.TypesInfo.Defs[funcDecl.Name].(*types.Func); isFunc {
+				decls[fn] = funcDecl
+			}
+		}
+	}
+
+	return decls
+}
+
+// calledFunc returns the function or method statically invoked by call, or nil.
+func calledFunc(call *ast.CallExpr, pass *analysis.Pass) *types.Func {
+	var id *ast.Ident
+
+	switch fun := unwrapParens(call.Fun).(type) {
+	case *ast.Ident:
+		id = fun
+	case *ast.SelectorExpr:
+		id = fun.Sel
+	case *ast.IndexExpr:
+		// Explicit instantiation of a generic function, e.g. f[T](...).
+		return calledFunc(&ast.CallExpr{Fun: fun.X}, pass)
+	default:
+		return nil
+	}
+
+	fn, isFunc := pass.TypesInfo.Uses[id].(*types.Func)
+	if !isFunc {
+		return nil
+	}
+
+	return fn.Origin()
+}
+
+// clientConstructor returns the qualified name of the driver client or
+// connection constructor invoked by call, or an empty string.
+func clientConstructor(call *ast.CallExpr, pass *analysis.Pass) string {
+	fn := calledFunc(call, pass)
+	if fn == nil || fn.Pkg() == nil {
+		return ""
+	}
+
+	path := fn.Pkg().Path()
+
+	switch {
+	case strings.HasSuffix(path, arangoPackageSuffix) && fn.Name() == funcNewClient,
+		strings.HasSuffix(path, connectionPackageSuffix) &&
+			(fn.Name() == funcNewHTTPConnection || fn.Name() == funcNewHTTP2Connection || fn.Name() == funcNewPool):
+		return fn.Pkg().Name() + "." + fn.Name()
+	default:
+		return ""
+	}
+}
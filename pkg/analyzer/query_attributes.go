@@ -0,0 +1,302 @@
+package analyzer
+
+import (
+	"go/ast"
+	"go/types"
+	"reflect"
+	"slices"
+	"strings"
+
+	"golang.org/x/tools/go/analysis"
+)
+
+const (
+	msgUnknownAttribute     = "attribute %s.%s does not match any json field of %s"
+	msgUnknownProjectionKey = "RETURN key %q does not match any json field of %s"
+	jsonTagKey              = "json"
+	jsonTagSkip             = "-"
+	aqlKeywordDistinct      = "DISTINCT"
+)
+
+// handleQueryAttributesCall cross-checks a constant query against the structs
+// its cursor decodes documents into with ReadDocument: the attributes accessed
+// on a returned loop variable, and the keys of a returned object, must match
+// json fields of the struct.
+func handleQueryAttributesCall(call *ast.CallExpr, pass *analysis.Pass, stack []ast.Node) {
+	methodName, queryArgIndex := identifyQueryMethod(call, pass)
+	if methodName != methodQuery || len(call.Args) <= queryArgIndex {
+		return
+	}
+
+	cursor := assignedCursor(call, pass, stack)
+	if cursor == nil {
+		return
+	}
+
+	query, known := constantString(call.Args[queryArgIndex], pass)
+	if !known {
+		return
+	}
+
+	parsed := parseAQL(query)
+
+	returned := returnClause(parsed)
+	if returned == nil {
+		return
+	}
+
+	for _, target := range cursorTargets(cursor, call, enclosingFunc(stack), pass) {
+		checkReturnedDocument(call.Args[queryArgIndex], parsed, returned, target, pass)
+	}
+}
+
+// assignedCursor returns the variable the cursor returned by call is assigned
+// to, or nil.
+func assignedCursor(call *ast.CallExpr, pass *analysis.Pass, stack []ast.Node) types.Object {
+	if len(stack) < 2 { //nolint:mnd // call and its parent
+		return nil
+	}
+
+	assign, isAssign := stack[len(stack)-2].(*ast.AssignStmt)
+	if !isAssign || len(assign.Rhs) != 1 || len(assign.Lhs) == 0 {
+		return nil
+	}
+
+	id, isIdent := assign.Lhs[0].(*ast.Ident)
+	if !isIdent || id.Name == "_" {
+		return nil
+	}
+
+	return pass.TypesInfo.ObjectOf(id)
+}
+
+// cursorTargets returns the struct types documents are read into from cursor
+// with ReadDocument in fn, after the query call and before the cursor variable
+// is assigned again.
+func cursorTargets(cursor types.Object, query *ast.CallExpr, fn ast.Node, pass *analysis.Pass) []types.Type {
+	if fn == nil {
+		return nil
+	}
+
+	limit := fn.End()
+
+	ast.Inspect(fn, func(node ast.Node) bool {
+		assign, isAssign := node.(*ast.AssignStmt)
+		if !isAssign || assign.Pos() <= query.End() || assign.Pos() >= limit {
+			return true
+		}
+
+		for _, lhs := range assign.Lhs {
+			if id, isIdent := lhs.(*ast.Ident); isIdent && pass.TypesInfo.ObjectOf(id) == cursor {
+				limit = assign.Pos()
+			}
+		}
+
+		return true
+	})
+
+	var targets []types.Type
+
+	ast.Inspect(fn, func(node ast.Node) bool {
+		call, isCall := node.(*ast.CallExpr)
+		if !isCall || call.Pos() <= query.End() || call.Pos() >= limit || len(call.Args) != 2 { //nolint:mnd // context and result
+			return true
+		}
+
+		method := arangoMethod(call, pass)
+		if method == nil || method.Name() != methodReadDocument || methodRecvTypeName(method) != cursorTypeName {
+			return true
+		}
+
+		receiver, isIdent := unwrapParens(call.Fun.(*ast.SelectorExpr).X).(*ast.Ident) //nolint:forcetypeassert // checked by arangoMethod
+		if !isIdent || pass.TypesInfo.Uses[receiver] != cursor {
+			return true
+		}
+
+		target := deref(pass.TypesInfo.TypeOf(call.Args[1]))
+		if _, isStruct := target.Underlying().(*types.Struct); isStruct &&
+			!slices.ContainsFunc(targets, func(other types.Type) bool { return types.Identical(other, target) }) {
+			targets = append(targets, target)
+		}
+
+		return true
+	})
+
+	return targets
+}
+
+// returnClause returns the top-level RETURN operation of query, or nil.
+func returnClause(query *aqlQuery) *aqlClause {
+	for _, clause := range slices.Backward(query.clauses) {
+		if clause.keyword == aqlKeywordReturn && clause.depth == 0 {
+			return clause
+		}
+	}
+
+	return nil
+}
+
+// checkReturnedDocument reports the attributes of a returned loop variable, or
+// the keys of a returned object literal, that do not match a json field of
+// target.
+func checkReturnedDocument(
+	queryArg ast.Expr,
+	query *aqlQuery,
+	returned *aqlClause,
+	target types.Type,
+	pass *analysis.Pass,
+) {
+	tokens := returned.tokens[1:]
+	if len(tokens) > 0 && tokens[0].isKeyword(aqlKeywordDistinct) {
+		tokens = tokens[1:]
+	}
+
+	if len(tokens) == 0 {
+		return
+	}
+
+	typeName := typeDisplayName(target)
+
+	if tokens[0].isPunct("{") {
+		for _, key := range objectKeys(tokens) {
+			if _, found := jsonField(target, key, false, nil); !found {
+				pass.Reportf(queryArg.Pos(), msgUnknownProjectionKey, key, typeName)
+			}
+		}
+
+		return
+	}
+
+	variable := tokens[0].text
+	if len(tokens) != 1 || tokens[0].kind != aqlName || !returnsLoopVariable(query, variable) {
+		return
+	}
+
+	var checked []string
+
+	for _, clause := range query.clauses {
+		if clause.depth != 0 {
+			continue
+		}
+
+		for _, attribute := range clause.attributes(variable) {
+			if slices.Contains(checked, attribute.String()) {
+				continue
+			}
+
+			checked = append(checked, attribute.String())
+
+			if !attributeExists(target, attribute.path) {
+				pass.Reportf(queryArg.Pos(), msgUnknownAttribute, variable, attribute, typeName)
+			}
+		}
+	}
+}
+
+// returnsLoopVariable reports whether variable is the variable of a top-level
+// FOR loop of query.
+func returnsLoopVariable(query *aqlQuery, variable string) bool {
+	return slices.ContainsFunc(query.loops, func(loop *aqlLoop) bool {
+		return loop.depth == 0 && loop.variable == variable
+	})
+}
+
+// objectKeys returns the constant keys of the object literal opening tokens,
+// including shorthand keys such as {name}. Computed keys are skipped.
+func objectKeys(tokens []aqlToken) []string {
+	var keys []string
+
+	depth := 0
+
+	for index, tok := range tokens {
+		switch {
+		case tok.isPunct("(", "[", "{"):
+			depth++
+		case tok.isPunct(")", "]", "}"):
+			depth--
+			if depth == 0 {
+				return keys
+			}
+		case depth == 1 && (tok.kind == aqlName || tok.kind == aqlString) && index > 0 && index+1 < len(tokens) &&
+			tokens[index-1].isPunct("{", ",") && tokens[index+1].isPunct(":", ",", "}"):
+			keys = append(keys, tok.text)
+		}
+	}
+
+	return keys
+}
+
+// attributeExists reports whether target, once encoded, has the attribute path.
+// AQL attribute names are case-sensitive, so each name must be the exact json
+// name of a field. System attributes always exist, and paths going through
+// fields that are not structs (maps, interfaces, ...) are not checked further.
+func attributeExists(target types.Type, path []string) bool {
+	if slices.Contains(indexedSystemAttributes, path[0]) || path[0] == systemAttrRev {
+		return true
+	}
+
+	current := target
+
+	for _, name := range path {
+		if _, isStruct := current.Underlying().(*types.Struct); !isStruct {
+			return true
+		}
+
+		fieldType, found := jsonField(current, name, true, nil)
+		if !found {
+			return false
+		}
+
+		current = deref(fieldType)
+	}
+
+	return true
+}
+
+// jsonField returns the type of the field of the struct t whose json name is
+// name: the tag name, or else the Go field name. exact selects the encoding
+// rule, where names must be equal; otherwise names match case-insensitively,
+// as when encoding/json decodes a key. Untagged embedded structs are
+// flattened; visited guards against recursive embedding.
+func jsonField(t types.Type, name string, exact bool, visited []*types.Struct) (types.Type, bool) {
+	structType, isStruct := t.Underlying().(*types.Struct)
+	if !isStruct || slices.Contains(visited, structType) {
+		return nil, false
+	}
+
+	visited = append(visited, structType)
+
+	for fieldIndex := range structType.NumFields() {
+		field := structType.Field(fieldIndex)
+		tagName, _, tagged := parseFieldTag(structType.Tag(fieldIndex), jsonTagKey)
+
+		if value, _ := reflect.StructTag(structType.Tag(fieldIndex)).Lookup(jsonTagKey); value == jsonTagSkip {
+			continue
+		}
+
+		if field.Anonymous() && !tagged {
+			if _, embedded := embeddedStruct(field.Type()); embedded != nil {
+				if fieldType, found := jsonField(deref(field.Type()), name, exact, visited); found {
+					return fieldType, true
+				}
+
+				continue
+			}
+		}
+
+		if !field.Exported() {
+			continue
+		}
+
+		fieldName := field.Name()
+		if tagged {
+			fieldName = tagName
+		}
+
+		if fieldName == name || (!exact && strings.EqualFold(fieldName, name)) {
+			return field.Type(), true
+		}
+	}
+
+	return nil, false
+}
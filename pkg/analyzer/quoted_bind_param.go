@@ -0,0 +1,116 @@
+package analyzer
+
+import (
+	"fmt"
+	"go/ast"
+	"go/token"
+	"go/types"
+	"strconv"
+	"strings"
+
+	"golang.org/x/tools/go/analysis"
+)
+
+const (
+	msgQuotedBindParam = "bind parameter %s is inside an AQL string literal; the string is compared as is"
+	bindParamPrefix    = "@"
+	// trailingPunctuation is trimmed from the words of a string, as in
+	// 'Hello @name, welcome'.
+	trailingPunctuation = ",.;:!?)"
+)
+
+// handleQuotedBindParamCall reports bind parameters written inside string
+// literals of constant queries passed to query methods, such as
+// FILTER u.name == '@name'. When the string holds only the parameter and the
+// query is a plain Go string literal, a fix removes the quotes.
+func handleQuotedBindParamCall(call *ast.CallExpr, pass *analysis.Pass) {
+	methodName, queryArgIndex := identifyQueryMethod(call, pass)
+	if methodName == "" || len(call.Args) <= queryArgIndex {
+		return
+	}
+
+	queryArg := call.Args[queryArgIndex]
+
+	query, known := constantString(queryArg, pass)
+	if !known {
+		return
+	}
+
+	literalStart, mappable := queryLiteralStart(queryArg, query, pass)
+
+	for _, tok := range lexAQL(query) {
+		if tok.kind != aqlString {
+			continue
+		}
+
+		if isBindParam(tok.text) {
+			diag := analysis.Diagnostic{
+				Pos:     queryArg.Pos(),
+				Message: fmt.Sprintf(msgQuotedBindParam, tok.text),
+			}
+
+			if mappable {
+				diag.SuggestedFixes = []analysis.SuggestedFix{{
+					Message: "Remove the quotes around " + tok.text,
+					TextEdits: []analysis.TextEdit{{
+						Pos:     literalStart + token.Pos(tok.offset),
+						End:     literalStart + token.Pos(tok.end),
+						NewText: []byte(tok.text),
+					}},
+				}}
+			}
+
+			pass.Report(diag)
+
+			continue
+		}
+
+		for _, word := range strings.Fields(tok.text) {
+			if word = strings.TrimRight(word, trailingPunctuation); isBindParam(word) {
+				pass.Reportf(queryArg.Pos(), msgQuotedBindParam, word)
+			}
+		}
+	}
+}
+
+// isBindParam reports whether text is a whole bind parameter, such as @name or
+// @@collection.
+func isBindParam(text string) bool {
+	name, found := strings.CutPrefix(text, bindParamPrefix)
+	if !found {
+		return false
+	}
+
+	name = strings.TrimPrefix(name, bindParamPrefix)
+
+	return name != "" && scanWhile(name, 0, isAQLNameChar) == len(name)
+}
+
+// queryLiteralStart returns the position of the first byte of query in the
+// source, when query is the verbatim content of a Go string literal: the query
+// argument itself, or the value of a constant or variable initialized with it.
+func queryLiteralStart(queryArg ast.Expr, query string, pass *analysis.Pass) (token.Pos, bool) {
+	expr := unwrapParens(queryArg)
+
+	if id, isIdent := expr.(*ast.Ident); isIdent {
+		obj := pass.TypesInfo.Uses[id]
+		if variable, isVar := obj.(*types.Var); isVar && isReassigned(variable, pass) {
+			return token.NoPos, false
+		}
+
+		expr = unwrapParens(localDefinitionValue(obj, pass))
+	}
+
+	literal, isLiteral := expr.(*ast.BasicLit)
+	if !isLiteral || literal.Kind != token.STRING {
+		return token.NoPos, false
+	}
+
+	// Escape sequences would shift the offsets of the query in the source.
+	if value, err := strconv.Unquote(literal.Value); err != nil || value != query ||
+		literal.Value[1:len(literal.Value)-1] != query {
+		return token.NoPos, false
+	}
+
+	return literal.Pos() + 1, true
+}
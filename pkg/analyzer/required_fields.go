@@ -0,0 +1,694 @@
+package analyzer
+
+import (
+	"errors"
+	"fmt"
+	"go/ast"
+	"go/token"
+	"go/types"
+	"slices"
+	"strings"
+
+	"golang.org/x/tools/go/analysis"
+)
+
+const (
+	msgMissingRequiredField = "missing %s option"
+	msgUnknownRequiredField = "-%s entry %s does not name a field of the arangodb package; it checks nothing"
+)
+
+var errInvalidRequiredField = errors.New("invalid required field, expected OptionsType.Field[.Field...][=message]")
+
+// requiredField is an options field that must be set explicitly at every
+// driver call taking its options type.
+type requiredField struct {
+	// typeName is the name of the options type in the arangodb package,
+	// e.g. BeginTransactionOptions.
+	typeName string
+	// path lists the field and its parents, e.g. [Options MaxRuntime].
+	path    []string
+	message string
+}
+
+// defaultRequiredFields lists the fields checked without configuration.
+var defaultRequiredFields = []requiredField{
+	{typeName: "BeginTransactionOptions", path: []string{allowImplicitFieldName}, message: msgMissingAllowImplicit},
+}
+
+// parseRequiredField parses an OptionsType.Field[.Field...][=message] entry.
+// Without a message, the diagnostic is "missing Field option".
+func parseRequiredField(value string) (requiredField, error) {
+	key, message, hasMessage := strings.Cut(value, "=")
+	if message = strings.TrimSpace(message); hasMessage && message == "" {
+		return requiredField{}, fmt.Errorf("%w: %q", errInvalidRequiredField, value)
+	}
+
+	parts := strings.Split(strings.TrimSpace(key), ".")
+	if len(parts) < 2 || slices.Contains(parts, "") { //nolint:mnd // type and field
+		return requiredField{}, fmt.Errorf("%w: %q", errInvalidRequiredField, value)
+	}
+
+	path := parts[1:]
+
+	if !hasMessage {
+		message = fmt.Sprintf(msgMissingRequiredField, strings.Join(path, "."))
+	}
+
+	return requiredField{typeName: parts[0], path: path, message: message}, nil
+}
+
+// String returns the OptionsType.Field[.Field...] form of the entry.
+func (f requiredField) String() string {
+	return f.typeName + "." + strings.Join(f.path, ".")
+}
+
+// handleRequiredFieldsCall validates the options arguments of driver calls
+// against the configured required fields. Analysis is intra-procedural and
+// flow/block-sensitive: it scans statements that appear before the call
+// within the nearest and ancestor blocks. For options produced by unknown
+// factory/helper calls, the analyzer remains conservative (assumes the field
+// is set) to avoid false positives that could annoy users.
+func handleRequiredFieldsCall(call *ast.CallExpr, pass *analysis.Pass, stack []ast.Node, cfg *config) {
+	method := arangoMethod(call, pass)
+	if method == nil {
+		return
+	}
+
+	sig, isSignature := method.Type().(*types.Signature)
+	if !isSignature {
+		return
+	}
+
+	params := sig.Params()
+
+	for paramIndex := 0; paramIndex < params.Len() && paramIndex < len(call.Args); paramIndex++ {
+		if sig.Variadic() && paramIndex == params.Len()-1 {
+			break
+		}
+
+		optsType := deref(params.At(paramIndex).Type())
+		typeName := arangoTypeName(optsType)
+
+		for _, field := range cfg.requiredFields {
+			if field.typeName != typeName || !hasFieldPath(optsType, field.path) {
+				continue
+			}
+
+			// Normalize the argument by unwrapping parentheses
+			arg := unwrapParens(call.Args[paramIndex])
+
+			if shouldReportMissingField(arg, field, pass, stack, call.Pos()) {
+				pass.Report(analysis.Diagnostic{
+					Pos:     call.Args[paramIndex].Pos(),
+					Message: field.message,
+				})
+			}
+		}
+	}
+}
+
+// hasFieldPath reports whether the struct type t has the nested field path.
+// Entries naming fields unknown to the driver version in use are reported by
+// reportUnknownRequiredFields.
+func hasFieldPath(t types.Type, path []string) bool {
+	for _, name := range path {
+		obj, _, _ := types.LookupFieldOrMethod(t, true, nil, name)
+
+		fieldVar, isVar := obj.(*types.Var)
+		if !isVar || !fieldVar.IsField() {
+			return false
+		}
+
+		t = deref(fieldVar.Type())
+	}
+
+	return true
+}
+
+// reportUnknownRequiredFields reports, once per package importing the driver,
+// the configured required fields whose options type or field does not exist in
+// the driver version in use, such as a misspelled entry. The diagnostic is
+// attached to the first import of the arangodb package.
+func reportUnknownRequiredFields(pass *analysis.Pass, cfg *config) {
+	for _, file := range pass.Files {
+		for _, spec := range file.Imports {
+			pkgName, isPkgName := importedPkgName(spec, pass).(*types.PkgName)
+			if !isPkgName || !strings.HasSuffix(pkgName.Imported().Path(), arangoPackageSuffix) {
+				continue
+			}
+
+			for _, field := range cfg.requiredFields {
+				typeName, isTypeName := pkgName.Imported().Scope().Lookup(field.typeName).(*types.TypeName)
+				if !isTypeName || !hasFieldPath(typeName.Type(), field.path) {
+					pass.Reportf(spec.Pos(), msgUnknownRequiredField, flagRequiredFields, field)
+				}
+			}
+
+			return
+		}
+	}
+}
+
+// shouldReportMissingField returns true when the provided options argument
+// expression should trigger the missing field diagnostic, and false when the
+// argument is known to have the field set (or when we must stay conservative
+// and avoid reporting).
+func shouldReportMissingField(
+	arg ast.Expr,
+	field requiredField,
+	pass *analysis.Pass,
+	stack []ast.Node,
+	callPos token.Pos,
+) bool {
+	switch optsExpr := arg.(type) {
+	case *ast.Ident:
+		// direct identifier or nil
+		if isNilIdent(optsExpr) {
+			return true
+		}
+
+		return !hasFieldForIdent(optsExpr, field, pass, stack, callPos)
+
+	case *ast.UnaryExpr:
+		// &CompositeLit or &ident or &index
+		if has, ok := compositeSetsField(optsExpr, field.path); ok {
+			return !has
+		}
+		// not a composite literal, try &ident
+		if id, ok := optsExpr.X.(*ast.Ident); ok {
+			return !hasFieldForIdent(id, field, pass, stack, callPos)
+		}
+		// not &ident, try &index (e.g., &arr[i])
+		if idx, ok := optsExpr.X.(*ast.IndexExpr); ok {
+			return !hasFieldForIndex(idx, field, pass, stack, callPos)
+		}
+		// Unknown &shape: stay conservative (do not report)
+		return false
+
+	case *ast.SelectorExpr:
+		// s.opts (or nested) passed as options
+		return !hasFieldForSelector(optsExpr, field, pass, stack, callPos)
+
+	case *ast.IndexExpr:
+		// opts passed as an indexed element, e.g., arr[i]
+		return !hasFieldForIndex(optsExpr, field, pass, stack, callPos)
+
+	case *ast.CallExpr:
+		// Typed conversion like (*arangodb.BeginTransactionOptions)(nil)
+		if isTypeConversionToPtrNil(optsExpr, pass) {
+			return true
+		}
+		// For other calls (factory/helpers), we stay conservative to avoid false positives.
+		return false
+	}
+
+	// Default: unknown expression shapes — stay conservative and do not report.
+	return false
+}
+
+// assignedFieldOwner reports whether assigning rhs to lhs sets the field path,
+// either directly (opts.Options.MaxRuntime = ...) or through a composite
+// literal assigned to a parent field (opts.Options = QuerySubOptions{MaxRuntime: ...}).
+// It returns the expression owning the path, e.g. opts.
+func assignedFieldOwner(lhs, rhs ast.Expr, path []string) (ast.Expr, bool) {
+	names := make([]string, 0, len(path))
+	owner := lhs
+
+	for depth := 1; depth <= len(path); depth++ {
+		sel, isSelector := owner.(*ast.SelectorExpr)
+		if !isSelector || sel.Sel == nil {
+			return nil, false
+		}
+
+		names = append([]string{sel.Sel.Name}, names...)
+		owner = sel.X
+
+		if !slices.Equal(names, path[:depth]) {
+			continue
+		}
+
+		if depth == len(path) {
+			return owner, true
+		}
+
+		if has, _ := compositeSetsField(rhs, path[depth:]); has {
+			return owner, true
+		}
+	}
+
+	return nil, false
+}
+
+// compositeSetsField reports whether expr is a composite literal (or address-of
+// one) that sets the field path through nested keyed elements. It returns
+// (has, ok) where ok indicates the expression was a recognized composite
+// literal shape. Nested values that are not composite literals are assumed to
+// set the rest of the path.
+func compositeSetsField(expr ast.Expr, path []string) (bool, bool) {
+	expr = unwrapParens(expr)
+
+	// handle address-of &CompositeLit
+	if ue, ok := expr.(*ast.UnaryExpr); ok {
+		expr = unwrapParens(ue.X)
+	}
+
+	// handle CompositeLit
+	cl, ok := expr.(*ast.CompositeLit)
+	if !ok {
+		return false, false
+	}
+
+	for _, elt := range cl.Elts {
+		kv, ok := elt.(*ast.KeyValueExpr)
+		if !ok {
+			continue
+		}
+
+		if ident, ok := kv.Key.(*ast.Ident); !ok || ident.Name != path[0] {
+			continue
+		}
+
+		if len(path) == 1 {
+			return true, true
+		}
+
+		if has, ok := compositeSetsField(kv.Value, path[1:]); ok {
+			return has, true
+		}
+
+		return true, true
+	}
+
+	return false, true
+}
+
+// hasFieldForSelector checks if a selector expression (e.g., s.opts)
+// has had the field set prior to the call position within
+// the nearest or any ancestor block. This is a conservative intra-procedural check.
+func hasFieldForSelector(
+	sel *ast.SelectorExpr,
+	field requiredField,
+	pass *analysis.Pass,
+	stack []ast.Node,
+	callPos token.Pos,
+) bool {
+	blocks := ancestorBlocks(stack)
+
+	// Special case: selector rooted at an index expression, e.g., arr[i].opts.
+	// In this case we must match both the base array/slice object and the specific index.
+	if innerIdx, ok := sel.X.(*ast.IndexExpr); ok {
+		return scanPriorStatements(blocks, callPos, func(stmt ast.Stmt) bool {
+			return setsFieldForIndex(stmt, innerIdx, field, pass)
+		})
+	}
+
+	// Resolve the root identifier (handles ident, parens, star, chained selectors)
+	root := rootIdent(sel)
+	if root == nil {
+		return false
+	}
+
+	rootObj := pass.TypesInfo.ObjectOf(root)
+	if rootObj == nil {
+		return false
+	}
+
+	return scanPriorStatements(blocks, callPos, func(stmt ast.Stmt) bool {
+		return setsFieldForObjectInAssign(stmt, rootObj, field, pass)
+	})
+}
+
+// setsFieldForObjectInAssign reports true if the statement assigns to the
+// field of X and the root identifier of X matches the provided object.
+func setsFieldForObjectInAssign(stmt ast.Stmt, obj types.Object, field requiredField, pass *analysis.Pass) bool {
+	assign, ok := stmt.(*ast.AssignStmt)
+	if !ok {
+		return false
+	}
+
+	for lhsIndex, lhs := range assign.Lhs {
+		owner, sets := assignedFieldOwner(lhs, getRHSForLHS(assign, lhsIndex), field.path)
+		if !sets {
+			continue
+		}
+
+		// Root resolution handles ident, parens, star, chained selectors and
+		// index roots like arr[i].opts.
+		if r := rootIdent(owner); r != nil && pass.TypesInfo.ObjectOf(r) == obj {
+			return true
+		}
+	}
+
+	return false
+}
+
+// hasFieldForIdent checks whether the given identifier (variable or pointer to options)
+// has the field explicitly set before the call position within the nearest or any ancestor block.
+func hasFieldForIdent(
+	id *ast.Ident,
+	field requiredField,
+	pass *analysis.Pass,
+	stack []ast.Node,
+	callPos token.Pos,
+) bool {
+	obj := pass.TypesInfo.ObjectOf(id)
+	if obj == nil {
+		return false
+	}
+
+	blocks := ancestorBlocks(stack)
+	// Walk from the nearest block outward and scan statements before the call position
+	if scanPriorStatements(blocks, callPos, func(stmt ast.Stmt) bool {
+		return stmtSetsFieldForObj(stmt, obj, field, pass)
+	}) {
+		return true
+	}
+
+	// If not found in local/ancestor blocks, also check for package-level (global)
+	// variable declarations that initialize the field.
+	return hasFieldForPackageVar(pass, obj, field)
+}
+
+func initHasFieldForObj(
+	assign *ast.AssignStmt,
+	obj types.Object,
+	field requiredField,
+	pass *analysis.Pass,
+) bool {
+	// find the RHS corresponding to our obj
+	for lhsIndex, lhs := range assign.Lhs {
+		id, ok := lhs.(*ast.Ident)
+		if !ok {
+			continue
+		}
+
+		if pass.TypesInfo.ObjectOf(id) != obj {
+			continue
+		}
+
+		rhsValue := getRHSForLHS(assign, lhsIndex)
+		if rhsValue == nil {
+			continue
+		}
+
+		// Check for the field in either &CompositeLit or CompositeLit via helper
+		if has, ok := compositeSetsField(rhsValue, field.path); ok {
+			return has
+		}
+	}
+
+	return false
+}
+
+func declInitHasFieldForObj(stmt ast.Stmt, obj types.Object, field requiredField, pass *analysis.Pass) bool {
+	declStmt, isDeclStmt := stmt.(*ast.DeclStmt)
+	if !isDeclStmt {
+		return false
+	}
+
+	genDecl, isGenDecl := declStmt.Decl.(*ast.GenDecl)
+	if !isGenDecl || genDecl.Tok != token.VAR {
+		return false
+	}
+
+	for _, spec := range genDecl.Specs {
+		valueSpec, isValueSpec := spec.(*ast.ValueSpec)
+		if !isValueSpec {
+			continue
+		}
+
+		if valueSpecHasFieldForObj(valueSpec, obj, field, pass) {
+			return true
+		}
+	}
+
+	return false
+}
+
+func valueSpecHasFieldForObj(
+	valueSpec *ast.ValueSpec,
+	obj types.Object,
+	field requiredField,
+	pass *analysis.Pass,
+) bool {
+	// find the index corresponding to our obj
+	targetIndex := -1
+
+	for i, name := range valueSpec.Names {
+		if pass.TypesInfo.ObjectOf(name) == obj {
+			targetIndex = i
+
+			break
+		}
+	}
+
+	if targetIndex == -1 {
+		return false
+	}
+
+	// pick the value expression for this name
+	var rhsValue ast.Expr
+
+	switch {
+	case targetIndex < len(valueSpec.Values):
+		rhsValue = valueSpec.Values[targetIndex]
+	case len(valueSpec.Values) == 1:
+		rhsValue = valueSpec.Values[0]
+	default:
+		return false
+	}
+
+	// Check for the field in either &CompositeLit or CompositeLit via helper
+	if has, ok := compositeSetsField(rhsValue, field.path); ok {
+		return has
+	}
+
+	return false
+}
+
+func stmtSetsFieldForObj(stmt ast.Stmt, obj types.Object, field requiredField, pass *analysis.Pass) bool {
+	// Direct assignment like opts.AllowImplicit = true
+	if setsFieldForObjectInAssign(stmt, obj, field, pass) {
+		return true
+	}
+
+	// Variable initialization via assignment (short var or regular assignment)
+	if assign, ok := stmt.(*ast.AssignStmt); ok {
+		if initHasFieldForObj(assign, obj, field, pass) {
+			return true
+		}
+	}
+
+	// Variable declaration with initialization
+	if declInitHasFieldForObj(stmt, obj, field, pass) {
+		return true
+	}
+
+	// Control-flow constructs that may contain relevant prior mutations/initializations
+	switch stmtNode := stmt.(type) {
+	case *ast.IfStmt:
+		return handleIfField(stmtNode, obj, field, pass)
+	case *ast.ForStmt:
+		return handleForField(stmtNode, obj, field, pass)
+	case *ast.RangeStmt:
+		return handleRangeField(stmtNode, obj, field, pass)
+	case *ast.SwitchStmt:
+		return handleSwitchField(stmtNode, obj, field, pass)
+	}
+
+	return false
+}
+
+// isTypeConversionToPtrNil reports whether call is a type conversion to a
+// pointer type with a single nil argument, e.g. (*arangodb.BeginTransactionOptions)(nil).
+// This recognizes explicit nil options passed via a cast.
+func isTypeConversionToPtrNil(call *ast.CallExpr, pass *analysis.Pass) bool {
+	// single arg must be a nil identifier
+	if len(call.Args) != 1 {
+		return false
+	}
+
+	if !isNilIdent(call.Args[0]) {
+		return false
+	}
+	// Check the target type is a pointer type
+	if t := pass.TypesInfo.TypeOf(call.Fun); t != nil {
+		if _, ok := t.(*types.Pointer); ok {
+			return true
+		}
+	}
+
+	// Fallback to a syntactic check
+	_, ok := unwrapParens(call.Fun).(*ast.StarExpr)
+
+	return ok
+}
+
+// hasFieldForPackageVar scans all files for top-level var declarations
+// of the given object and returns true if its initialization sets the field.
+func hasFieldForPackageVar(pass *analysis.Pass, obj types.Object, field requiredField) bool {
+	// Only variables can be relevant here, but the object identity check below
+	// will safely no-op for others.
+	for _, f := range pass.Files {
+		for _, decl := range f.Decls {
+			genDecl, ok := decl.(*ast.GenDecl)
+			if !ok || genDecl.Tok != token.VAR {
+				continue
+			}
+
+			for _, spec := range genDecl.Specs {
+				valueSpec, ok := spec.(*ast.ValueSpec)
+				if !ok {
+					continue
+				}
+
+				if valueSpecHasFieldForObj(valueSpec, obj, field, pass) {
+					return true
+				}
+			}
+		}
+	}
+
+	return false
+}
+
+// handleIfField scans the if statement's body and else branches for assignments or
+// initializations that set the field for the given object.
+func handleIfField(stmtNode *ast.IfStmt, obj types.Object, field requiredField, pass *analysis.Pass) bool {
+	// Recurse into body statements
+	for _, st := range stmtNode.Body.List {
+		if stmtSetsFieldForObj(st, obj, field, pass) {
+			return true
+		}
+	}
+	// Else can be another IfStmt (else-if) or a BlockStmt
+	switch elseNode := stmtNode.Else.(type) {
+	case *ast.BlockStmt:
+		for _, st := range elseNode.List {
+			if stmtSetsFieldForObj(st, obj, field, pass) {
+				return true
+			}
+		}
+	case *ast.IfStmt:
+		if stmtSetsFieldForObj(elseNode, obj, field, pass) {
+			return true
+		}
+	}
+
+	return false
+}
+
+// handleForField scans a for statement's init and body for relevant initializations/assignments.
+func handleForField(stmtNode *ast.ForStmt, obj types.Object, field requiredField, pass *analysis.Pass) bool {
+	// e.g., for i := 0; i < n; i++ { opts.AllowImplicit = true }
+	if assign, ok := stmtNode.Init.(*ast.AssignStmt); ok {
+		if initHasFieldForObj(assign, obj, field, pass) {
+			return true
+		}
+	}
+
+	for _, st := range stmtNode.Body.List {
+		if stmtSetsFieldForObj(st, obj, field, pass) {
+			return true
+		}
+	}
+
+	return false
+}
+
+// handleSwitchField scans a switch statement's init and case bodies.
+func handleSwitchField(
+	stmtNode *ast.SwitchStmt,
+	obj types.Object,
+	field requiredField,
+	pass *analysis.Pass,
+) bool {
+	if assign, ok := stmtNode.Init.(*ast.AssignStmt); ok {
+		if initHasFieldForObj(assign, obj, field, pass) {
+			return true
+		}
+	}
+
+	for _, cc := range stmtNode.Body.List {
+		if clause, ok := cc.(*ast.CaseClause); ok {
+			for _, st := range clause.Body {
+				if stmtSetsFieldForObj(st, obj, field, pass) {
+					return true
+				}
+			}
+		}
+	}
+
+	return false
+}
+
+// handleRangeField scans a range statement's body for assignments or initializations
+// that set the field for the given object. Mirrors ForStmt handling semantics.
+func handleRangeField(stmtNode *ast.RangeStmt, obj types.Object, field requiredField, pass *analysis.Pass) bool {
+	if stmtNode == nil || stmtNode.Body == nil {
+		return false
+	}
+
+	for _, st := range stmtNode.Body.List {
+		if stmtSetsFieldForObj(st, obj, field, pass) {
+			return true
+		}
+	}
+
+	return false
+}
+
+// hasFieldForIndex checks if an index expression (e.g., arr[i] or arr[i].<field> via nested selectors)
+// refers to an array/slice element whose field was set prior to the call position within
+// the nearest or any ancestor block. We require both the same base identifier and the same index (when resolvable).
+func hasFieldForIndex(
+	idx *ast.IndexExpr,
+	field requiredField,
+	pass *analysis.Pass,
+	stack []ast.Node,
+	callPos token.Pos,
+) bool {
+	if idx == nil {
+		return false
+	}
+
+	blocks := ancestorBlocks(stack)
+
+	return scanPriorStatements(blocks, callPos, func(stmt ast.Stmt) bool {
+		return setsFieldForIndex(stmt, idx, field, pass)
+	})
+}
+
+// setsFieldForIndex reports true if stmt assigns to the field for the
+// specific element referenced by target (matching both base and index).
+func setsFieldForIndex(stmt ast.Stmt, target *ast.IndexExpr, field requiredField, pass *analysis.Pass) bool {
+	assign, ok := stmt.(*ast.AssignStmt)
+	if !ok {
+		return false
+	}
+
+	for lhsIndex, lhs := range assign.Lhs {
+		owner, sets := assignedFieldOwner(lhs, getRHSForLHS(assign, lhsIndex), field.path)
+		if !sets {
+			continue
+		}
+
+		// Direct element field assignment: arr[i].AllowImplicit = ...
+		if idx, ok := owner.(*ast.IndexExpr); ok {
+			if sameIndexBase(idx, target, pass) {
+				return true
+			}
+		}
+
+		// Nested field after element selection: arr[i].opts.AllowImplicit = ...
+		if innerSel, ok := owner.(*ast.SelectorExpr); ok {
+			if idx, ok := innerSel.X.(*ast.IndexExpr); ok {
+				if sameIndexBase(idx, target, pass) {
+					return true
+				}
+			}
+		}
+	}
+
+	return false
+}
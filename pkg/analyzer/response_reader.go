@@ -0,0 +1,397 @@
+package analyzer
+
+import (
+	"go/ast"
+	"go/token"
+	"go/types"
+	"strings"
+
+	"golang.org/x/tools/go/analysis"
+	"golang.org/x/tools/go/analysis/passes/ctrlflow"
+	"golang.org/x/tools/go/cfg"
+)
+
+const (
+	msgReaderDiscarded        = "response reader returned by %s is discarded; per-document errors are only reported by Read"
+	msgReaderNotDrained       = "response reader returned by %s is not read until shared.IsNoMoreDocuments"
+	msgReaderNotDrainedOnPath = "response reader returned by %s is not drained on every path"
+	responseReaderPrefix      = "CollectionDocument"
+	responseReaderSuffix      = "ResponseReader"
+	methodRead                = "Read"
+	funcIsNoMoreDocuments     = "IsNoMoreDocuments"
+	sharedPackageSuffix       = "github.com/arangodb/go-driver/v2/arangodb/shared"
+)
+
+// handleResponseReaderCall validates that the response readers returned by
+// batch document methods (CreateDocuments, ReadDocuments, ...) are drained:
+// per-document errors only surface when Read is called until
+// shared.IsNoMoreDocuments. Readers that escape the current function (passed
+// to a helper, returned, stored in a field, ...) are not reported.
+func handleResponseReaderCall(call *ast.CallExpr, pass *analysis.Pass, stack []ast.Node) {
+	method := arangoMethod(call, pass)
+	if method == nil || !returnsResponseReader(method) || len(stack) < 2 {
+		return
+	}
+
+	bindNode := stack[len(stack)-2]
+
+	readerIdent, errIdent, discarded := resultBinding(call, bindNode)
+	if discarded {
+		pass.Reportf(call.Pos(), msgReaderDiscarded, method.Name())
+
+		return
+	}
+
+	if readerIdent == nil {
+		return
+	}
+
+	reader := pass.TypesInfo.ObjectOf(readerIdent)
+	fn := enclosingFunc(stack)
+	body := funcBody(fn)
+
+	if reader == nil || body == nil || readerEscapes(body, reader, pass) {
+		return
+	}
+
+	loops := drainLoops(body, reader, pass)
+	if len(loops) == 0 {
+		pass.Reportf(call.Pos(), msgReaderNotDrained, method.Name())
+
+		return
+	}
+
+	graph := funcCFG(fn, pass)
+	if graph == nil {
+		return
+	}
+
+	var errObj types.Object
+	if errIdent != nil {
+		errObj = pass.TypesInfo.ObjectOf(errIdent)
+	}
+
+	if hasUndrainedPath(graph, bindNode, loops, errObj, pass) {
+		pass.Reportf(call.Pos(), msgReaderNotDrainedOnPath, method.Name())
+	}
+}
+
+// returnsResponseReader reports whether method returns one of the
+// CollectionDocument*ResponseReader types as its first result.
+func returnsResponseReader(method *types.Func) bool {
+	sig, isSignature := method.Type().(*types.Signature)
+	if !isSignature || sig.Results().Len() == 0 {
+		return false
+	}
+
+	named, isNamed := sig.Results().At(0).Type().(*types.Named)
+	if !isNamed {
+		return false
+	}
+
+	name := named.Obj().Name()
+
+	return strings.HasPrefix(name, responseReaderPrefix) && strings.HasSuffix(name, responseReaderSuffix)
+}
+
+// resultBinding inspects the node enclosing a call returning a value and an
+// error (a response reader, a transaction, ...) and returns the identifiers
+// both results are bound to. discarded is true when the value is dropped
+// (expression statement, defer/go, or assigned to the blank identifier). A nil
+// result identifier without discarded means the value flows somewhere we do
+// not track.
+func resultBinding(call *ast.CallExpr, parent ast.Node) (resultIdent, errIdent *ast.Ident, discarded bool) {
+	var lhs []ast.Expr
+
+	switch typed := parent.(type) {
+	case *ast.ExprStmt, *ast.DeferStmt, *ast.GoStmt:
+		return nil, nil, true
+	case *ast.AssignStmt:
+		if len(typed.Rhs) != 1 || typed.Rhs[0] != call {
+			return nil, nil, false
+		}
+
+		lhs = typed.Lhs
+	case *ast.ValueSpec:
+		if len(typed.Values) != 1 || typed.Values[0] != call {
+			return nil, nil, false
+		}
+
+		for _, name := range typed.Names {
+			lhs = append(lhs, name)
+		}
+	default:
+		return nil, nil, false
+	}
+
+	ident, isIdent := lhs[0].(*ast.Ident)
+	if !isIdent {
+		return nil, nil, false
+	}
+
+	if ident.Name == "_" {
+		return nil, nil, true
+	}
+
+	if len(lhs) > 1 {
+		if errID, isErrIdent := lhs[1].(*ast.Ident); isErrIdent && errID.Name != "_" {
+			errIdent = errID
+		}
+	}
+
+	return ident, errIdent, false
+}
+
+// funcBody returns the body of a function declaration or literal.
+func funcBody(fn ast.Node) *ast.BlockStmt {
+	switch typed := fn.(type) {
+	case *ast.FuncDecl:
+		return typed.Body
+	case *ast.FuncLit:
+		return typed.Body
+	default:
+		return nil
+	}
+}
+
+// funcCFG returns the control-flow graph of a function declaration or literal.
+func funcCFG(fn ast.Node, pass *analysis.Pass) *cfg.CFG {
+	cfgs, isCFGs := pass.ResultOf[ctrlflow.Analyzer].(*ctrlflow.CFGs)
+	if !isCFGs {
+		return nil
+	}
+
+	switch typed := fn.(type) {
+	case *ast.FuncDecl:
+		return cfgs.FuncDecl(typed)
+	case *ast.FuncLit:
+		return cfgs.FuncLit(typed)
+	default:
+		return nil
+	}
+}
+
+// readerEscapes reports whether obj is used in body for anything other than
+// calling its Read method or being assigned to.
+func readerEscapes(body *ast.BlockStmt, obj types.Object, pass *analysis.Pass) bool {
+	allowed := make(map[*ast.Ident]bool)
+
+	ast.Inspect(body, func(node ast.Node) bool {
+		switch typed := node.(type) {
+		case *ast.CallExpr:
+			if receiver := methodCallReceiver(typed, methodRead); receiver != nil {
+				allowed[receiver] = true
+			}
+		case *ast.AssignStmt:
+			for _, lhs := range typed.Lhs {
+				if id, isIdent := lhs.(*ast.Ident); isIdent {
+					allowed[id] = true
+				}
+			}
+		}
+
+		return true
+	})
+
+	escapes := false
+
+	ast.Inspect(body, func(node ast.Node) bool {
+		if id, isIdent := node.(*ast.Ident); isIdent && !allowed[id] && pass.TypesInfo.Uses[id] == obj {
+			escapes = true
+		}
+
+		return !escapes
+	})
+
+	return escapes
+}
+
+// methodCallReceiver returns the receiver identifier of call when it is a
+// call to a method named methodName on a plain identifier.
+func methodCallReceiver(call *ast.CallExpr, methodName string) *ast.Ident {
+	selExpr, isSelector := call.Fun.(*ast.SelectorExpr)
+	if !isSelector || selExpr.Sel == nil || selExpr.Sel.Name != methodName {
+		return nil
+	}
+
+	receiver, isIdent := unwrapParens(selExpr.X).(*ast.Ident)
+	if !isIdent {
+		return nil
+	}
+
+	return receiver
+}
+
+// drainLoops returns the for loops of body that call obj.Read and check
+// shared.IsNoMoreDocuments, i.e. loops that iterate the reader to exhaustion.
+func drainLoops(body *ast.BlockStmt, obj types.Object, pass *analysis.Pass) []*ast.ForStmt {
+	var loops []*ast.ForStmt
+
+	ast.Inspect(body, func(node ast.Node) bool {
+		loop, isFor := node.(*ast.ForStmt)
+		if !isFor {
+			return true
+		}
+
+		if callsMethodOn(loop.Body, obj, methodRead, pass) && containsSharedCall(loop, funcIsNoMoreDocuments, pass) {
+			loops = append(loops, loop)
+		}
+
+		return true
+	})
+
+	return loops
+}
+
+// callsMethodOn reports whether node contains a call to obj.<methodName>(...).
+func callsMethodOn(node ast.Node, obj types.Object, methodName string, pass *analysis.Pass) bool {
+	found := false
+
+	ast.Inspect(node, func(child ast.Node) bool {
+		if call, isCall := child.(*ast.CallExpr); isCall {
+			if receiver := methodCallReceiver(call, methodName); receiver != nil &&
+				pass.TypesInfo.ObjectOf(receiver) == obj {
+				found = true
+			}
+		}
+
+		return !found
+	})
+
+	return found
+}
+
+// containsSharedCall reports whether node contains a call to the given
+// function of the arangodb/shared package.
+func containsSharedCall(node ast.Node, funcName string, pass *analysis.Pass) bool {
+	found := false
+
+	ast.Inspect(node, func(child ast.Node) bool {
+		if call, isCall := child.(*ast.CallExpr); isCall && isSharedFuncCall(call, funcName, pass) {
+			found = true
+		}
+
+		return !found
+	})
+
+	return found
+}
+
+// isSharedFuncCall reports whether call invokes funcName from the arangodb/shared package.
+func isSharedFuncCall(call *ast.CallExpr, funcName string, pass *analysis.Pass) bool {
+	var id *ast.Ident
+
+	switch fun := unwrapParens(call.Fun).(type) {
+	case *ast.Ident:
+		id = fun
+	case *ast.SelectorExpr:
+		id = fun.Sel
+	default:
+		return false
+	}
+
+	fn, isFunc := pass.TypesInfo.Uses[id].(*types.Func)
+
+	return isFunc && fn.Name() == funcName && fn.Pkg() != nil &&
+		strings.HasSuffix(fn.Pkg().Path(), sharedPackageSuffix)
+}
+
+// hasUndrainedPath reports whether a return statement is reachable from
+// bindNode without entering one of the drain loops. Branches taken only when
+// the call failed (err != nil) are skipped since the reader is nil there.
+func hasUndrainedPath(
+	graph *cfg.CFG,
+	bindNode ast.Node,
+	loops []*ast.ForStmt,
+	errObj types.Object,
+	pass *analysis.Pass,
+) bool {
+	inLoop := func(node ast.Node) bool {
+		for _, loop := range loops {
+			if node.Pos() >= loop.Pos() && node.End() <= loop.End() {
+				return true
+			}
+		}
+
+		return false
+	}
+
+	visited := make(map[*cfg.Block]bool)
+
+	var walk func(block *cfg.Block, start int) bool
+
+	walk = func(block *cfg.Block, start int) bool {
+		for _, node := range block.Nodes[start:] {
+			if inLoop(node) {
+				return false
+			}
+		}
+
+		if len(block.Succs) == 0 {
+			return len(block.Nodes) > 0 && isReturnStmt(block.Nodes[len(block.Nodes)-1])
+		}
+
+		succs := block.Succs
+		if len(succs) == 2 && errObj != nil { //nolint:mnd // conditional blocks have two successors
+			switch errNilComparison(block.Nodes[len(block.Nodes)-1], errObj, pass) {
+			case token.NEQ:
+				succs = succs[1:]
+			case token.EQL:
+				succs = succs[:1]
+			default:
+			}
+		}
+
+		for _, succ := range succs {
+			if visited[succ] {
+				continue
+			}
+
+			visited[succ] = true
+
+			if walk(succ, 0) {
+				return true
+			}
+		}
+
+		return false
+	}
+
+	for _, block := range graph.Blocks {
+		for nodeIndex, node := range block.Nodes {
+			if node == bindNode {
+				return walk(block, nodeIndex+1)
+			}
+		}
+	}
+
+	return false
+}
+
+// isReturnStmt reports whether node is a return statement.
+func isReturnStmt(node ast.Node) bool {
+	_, isReturn := node.(*ast.ReturnStmt)
+
+	return isReturn
+}
+
+// errNilComparison returns the operator of node when it compares errObj with
+// nil (err != nil or err == nil), and token.ILLEGAL otherwise.
+func errNilComparison(node ast.Node, errObj types.Object, pass *analysis.Pass) token.Token {
+	binExpr, isBinary := node.(*ast.BinaryExpr)
+	if !isBinary || (binExpr.Op != token.NEQ && binExpr.Op != token.EQL) {
+		return token.ILLEGAL
+	}
+
+	left, right := unwrapParens(binExpr.X), unwrapParens(binExpr.Y)
+	if isNilIdent(left) {
+		left, right = right, left
+	}
+
+	id, isIdent := left.(*ast.Ident)
+	if !isIdent || !isNilIdent(right) || pass.TypesInfo.ObjectOf(id) != errObj {
+		return token.ILLEGAL
+	}
+
+	return binExpr.Op
+}
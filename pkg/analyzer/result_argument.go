@@ -0,0 +1,104 @@
+package analyzer
+
+import (
+	"go/ast"
+	"go/types"
+
+	"golang.org/x/tools/go/analysis"
+)
+
+const (
+	resultParamName      = "result"
+	msgResultNonPointer  = "call of %s passes non-pointer as result argument"
+	msgResultNil         = "call of %s passes nil as result argument"
+	msgResultNotSlicePtr = "call of %s passes non-slice pointer as result argument"
+	methodReadNextBatch  = "ReadNextBatch"
+	methodRetryReadBatch = "RetryReadBatch"
+)
+
+// handleResultArgumentCall validates the "result interface{}" argument of
+// arangodb methods (Cursor.ReadDocument, CollectionDocuments.ReadDocument, ...).
+// Like vet's unmarshal pass, it reports values that cannot be unmarshaled into:
+// non-pointers and nil. Batch reads additionally require a pointer to a slice.
+func handleResultArgumentCall(call *ast.CallExpr, pass *analysis.Pass) {
+	method := arangoMethod(call, pass)
+	if method == nil {
+		return
+	}
+
+	sig, isSignature := method.Type().(*types.Signature)
+	if !isSignature {
+		return
+	}
+
+	params := sig.Params()
+	for paramIndex := range params.Len() {
+		param := params.At(paramIndex)
+		if param.Name() != resultParamName || !isEmptyInterface(param.Type()) {
+			continue
+		}
+
+		if paramIndex >= len(call.Args) {
+			return
+		}
+
+		checkResultArgument(call.Args[paramIndex], method.Name(), pass)
+	}
+}
+
+// checkResultArgument reports arg when it cannot receive the decoded result of method.
+func checkResultArgument(arg ast.Expr, methodName string, pass *analysis.Pass) {
+	typeAndValue, known := pass.TypesInfo.Types[arg]
+	if !known || typeAndValue.Type == nil {
+		return
+	}
+
+	if typeAndValue.IsNil() {
+		// QueryBatch explicitly skips decoding the first batch when result is nil.
+		if methodName != methodQueryBatch {
+			pass.Reportf(arg.Pos(), msgResultNil, methodName)
+		}
+
+		return
+	}
+
+	switch typed := typeAndValue.Type.Underlying().(type) {
+	case *types.Pointer:
+		if requiresSliceResult(methodName) && !isSliceOrUnknown(typed.Elem()) {
+			pass.Reportf(arg.Pos(), msgResultNotSlicePtr, methodName)
+		}
+	case *types.Interface, *types.TypeParam:
+		// Dynamic type unknown: stay conservative.
+	default:
+		pass.Reportf(arg.Pos(), msgResultNonPointer, methodName)
+	}
+}
+
+// requiresSliceResult reports whether methodName decodes a whole batch of
+// documents, which requires a pointer to a slice.
+func requiresSliceResult(methodName string) bool {
+	switch methodName {
+	case methodReadNextBatch, methodRetryReadBatch, methodQueryBatch:
+		return true
+	default:
+		return false
+	}
+}
+
+// isSliceOrUnknown reports whether t is a slice, or a type whose dynamic shape
+// cannot be determined statically (interfaces and type parameters).
+func isSliceOrUnknown(t types.Type) bool {
+	switch t.Underlying().(type) {
+	case *types.Slice, *types.Interface, *types.TypeParam:
+		return true
+	default:
+		return false
+	}
+}
+
+// isEmptyInterface reports whether t is interface{} (or any).
+func isEmptyInterface(t types.Type) bool {
+	iface, isInterface := t.Underlying().(*types.Interface)
+
+	return isInterface && iface.Empty()
+}
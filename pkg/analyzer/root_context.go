@@ -0,0 +1,243 @@
+package analyzer
+
+import (
+	"fmt"
+	"go/ast"
+	"go/token"
+	"go/types"
+
+	"golang.org/x/tools/go/analysis"
+)
+
+const (
+	msgRootContext     = "%s called with %s.%s(); use the in-scope context %s"
+	contextPackagePath = "context"
+	contextTypeName    = "Context"
+	funcBackground     = "Background"
+	funcTODO           = "TODO"
+	requestContextCall = ".Context()"
+)
+
+// handleRootContextCall reports driver calls given context.Background() or
+// context.TODO() while a request-scoped context is in scope: a context.Context
+// parameter or an *http.Request parameter of an enclosing function. Passing a
+// fresh root context drops cancellation and deadlines. A suggested fix swaps in
+// the in-scope context.
+func handleRootContextCall(call *ast.CallExpr, pass *analysis.Pass, stack []ast.Node) {
+	method := arangoMethod(call, pass)
+	if method == nil || len(call.Args) == 0 {
+		return
+	}
+
+	sig, isSignature := method.Type().(*types.Signature)
+	if !isSignature || sig.Params().Len() == 0 ||
+		!isNamedType(sig.Params().At(0).Type(), contextPackagePath, contextTypeName) {
+		return
+	}
+
+	ctxArg := unwrapParens(call.Args[0])
+
+	rootCall, isCall := ctxArg.(*ast.CallExpr)
+	if !isCall {
+		return
+	}
+
+	rootFunc := calledFunc(rootCall, pass)
+	if rootFunc == nil || rootFunc.Pkg() == nil || rootFunc.Pkg().Path() != contextPackagePath ||
+		(rootFunc.Name() != funcBackground && rootFunc.Name() != funcTODO) {
+		return
+	}
+
+	replacement := inScopeContext(stack, call, pass)
+	if replacement == "" {
+		return
+	}
+
+	edits := []analysis.TextEdit{{
+		Pos:     ctxArg.Pos(),
+		End:     ctxArg.End(),
+		NewText: []byte(replacement),
+	}}
+
+	if edit, unused := lastImportUseEdit(rootCall, pass); unused {
+		edits = append(edits, edit)
+	}
+
+	pass.Report(analysis.Diagnostic{
+		Pos:     ctxArg.Pos(),
+		End:     ctxArg.End(),
+		Message: fmt.Sprintf(msgRootContext, method.Name(), contextPackagePath, rootFunc.Name(), replacement),
+		SuggestedFixes: []analysis.SuggestedFix{{
+			Message:   "Use " + replacement,
+			TextEdits: edits,
+		}},
+	})
+}
+
+// lastImportUseEdit returns an edit deleting the import of the package
+// qualifying the function called by rootCall, when rootCall is the only use of
+// that import in its file.
+func lastImportUseEdit(rootCall *ast.CallExpr, pass *analysis.Pass) (analysis.TextEdit, bool) {
+	selExpr, isSelector := unwrapParens(rootCall.Fun).(*ast.SelectorExpr)
+	if !isSelector {
+		return analysis.TextEdit{}, false
+	}
+
+	qualifier, isIdent := selExpr.X.(*ast.Ident)
+	if !isIdent {
+		return analysis.TextEdit{}, false
+	}
+
+	pkgName, isPkgName := pass.TypesInfo.Uses[qualifier].(*types.PkgName)
+	file := fileAt(rootCall.Pos(), pass)
+
+	if !isPkgName || file == nil {
+		return analysis.TextEdit{}, false
+	}
+
+	for id, obj := range pass.TypesInfo.Uses {
+		if obj == pkgName && id != qualifier && file.FileStart <= id.Pos() && id.Pos() < file.FileEnd {
+			return analysis.TextEdit{}, false
+		}
+	}
+
+	for _, decl := range file.Decls {
+		genDecl, isGenDecl := decl.(*ast.GenDecl)
+		if !isGenDecl || genDecl.Tok != token.IMPORT {
+			continue
+		}
+
+		for _, spec := range genDecl.Specs {
+			importSpec, isImport := spec.(*ast.ImportSpec)
+			if !isImport || importedPkgName(importSpec, pass) != pkgName {
+				continue
+			}
+
+			// Delete the whole lines, or the whole declaration for a single
+			// unparenthesized import.
+			var start, end token.Pos = importSpec.Pos(), importSpec.End()
+			if importSpec.Doc != nil {
+				start = importSpec.Doc.Pos()
+			}
+
+			if !genDecl.Lparen.IsValid() {
+				start, end = genDecl.Pos(), genDecl.End()
+			}
+
+			tokFile := pass.Fset.File(end)
+			start = tokFile.LineStart(tokFile.Line(start))
+
+			if line := tokFile.Line(end); line < tokFile.LineCount() {
+				end = tokFile.LineStart(line + 1)
+			}
+
+			return analysis.TextEdit{Pos: start, End: end}, true
+		}
+	}
+
+	return analysis.TextEdit{}, false
+}
+
+// importedPkgName returns the package name declared by spec.
+func importedPkgName(spec *ast.ImportSpec, pass *analysis.Pass) types.Object {
+	if spec.Name != nil {
+		return pass.TypesInfo.Defs[spec.Name]
+	}
+
+	return pass.TypesInfo.Implicits[spec]
+}
+
+// inScopeContext returns the expression giving the request-scoped context of
+// the nearest enclosing function: its context.Context parameter, or the
+// Context() of its *http.Request parameter. It returns an empty string when
+// there is none, or when the parameter is shadowed at the call site. The search
+// stops at go statements and at the function literals they start: detached
+// background work may outlive the request, so a root context is legitimate
+// there.
+func inScopeContext(stack []ast.Node, call *ast.CallExpr, pass *analysis.Pass) string {
+	if len(stack) >= 2 { //nolint:mnd // call and its parent
+		if goStmt, isGo := stack[len(stack)-2].(*ast.GoStmt); isGo && goStmt.Call == call {
+			return ""
+		}
+	}
+
+	for index := len(stack) - 1; index >= 0; index-- {
+		var funcType *ast.FuncType
+
+		switch typed := stack[index].(type) {
+		case *ast.FuncDecl:
+			funcType = typed.Type
+		case *ast.FuncLit:
+			funcType = typed.Type
+		default:
+			continue
+		}
+
+		if expr := contextFromParams(funcType, call, pass); expr != "" {
+			return expr
+		}
+
+		if isGoroutineBody(stack, index) {
+			return ""
+		}
+	}
+
+	return ""
+}
+
+// isGoroutineBody reports whether stack[index] is the function literal
+// invoked by a go statement, as in go func() { ... }().
+func isGoroutineBody(stack []ast.Node, index int) bool {
+	if index < 2 { //nolint:mnd // go statement and call
+		return false
+	}
+
+	call, isCall := stack[index-1].(*ast.CallExpr)
+	if !isCall || unwrapParens(call.Fun) != stack[index] {
+		return false
+	}
+
+	_, isGo := stack[index-2].(*ast.GoStmt)
+
+	return isGo
+}
+
+// contextFromParams looks for a usable context among the parameters of funcType.
+func contextFromParams(funcType *ast.FuncType, call *ast.CallExpr, pass *analysis.Pass) string {
+	if funcType.Params == nil {
+		return ""
+	}
+
+	var requestExpr string
+
+	for _, field := range funcType.Params.List {
+		for _, name := range field.Names {
+			obj := pass.TypesInfo.Defs[name]
+			if obj == nil || name.Name == "_" || !visibleAt(obj, call, pass) {
+				continue
+			}
+
+			if isNamedType(obj.Type(), contextPackagePath, contextTypeName) {
+				return name.Name
+			}
+
+			if requestExpr == "" && isNamedType(deref(obj.Type()), httpPackagePath, "Request") {
+				requestExpr = name.Name + requestContextCall
+			}
+		}
+	}
+
+	return requestExpr
+}
+
+// visibleAt reports whether obj is the object its name resolves to at call.
+func visibleAt(obj types.Object, call *ast.CallExpr, pass *analysis.Pass) bool {
+	scope := pass.Pkg.Scope().Innermost(call.Pos())
+	if scope == nil {
+		return false
+	}
+
+	_, resolved := scope.LookupParent(obj.Name(), call.Pos())
+
+	return resolved == obj
+}
@@ -0,0 +1,157 @@
+package analyzer
+
+import (
+	"go/ast"
+	"go/types"
+	"slices"
+	"strings"
+
+	"golang.org/x/tools/go/analysis"
+)
+
+const (
+	msgSharedWithGoroutine = "%s is used from another goroutine; it does not support concurrent requests"
+	msgSentOverChannel     = "%s is sent over a channel; it does not support concurrent requests"
+	transactionTypeName    = "Transaction"
+	collectionTypeName     = "Collection"
+	methodGetCollection    = "GetCollection"
+	methodCollection       = "Collection"
+)
+
+// goroutineLauncher is a method running its function argument in a new goroutine.
+type goroutineLauncher struct {
+	pkgPath  string
+	typeName string
+	method   string
+}
+
+// goroutineLaunchers lists the known goroutine launchers. Package paths are
+// matched by suffix to support vendored copies.
+var goroutineLaunchers = []goroutineLauncher{
+	{pkgPath: "golang.org/x/sync/errgroup", typeName: "Group", method: "Go"},
+	{pkgPath: "golang.org/x/sync/errgroup", typeName: "Group", method: "TryGo"},
+	{pkgPath: "sync", typeName: "WaitGroup", method: "Go"},
+}
+
+// handleGoStmt reports transactions, cursors and collections obtained from a
+// transaction that are captured or passed by a go statement.
+func handleGoStmt(stmt *ast.GoStmt, pass *analysis.Pass) {
+	reportSharedValues(stmt.Call, stmt, pass)
+}
+
+// handleGoroutineLauncherCall reports transactions, cursors and collections
+// obtained from a transaction captured by a function passed to errgroup.Group.Go
+// or a similar launcher.
+func handleGoroutineLauncherCall(call *ast.CallExpr, pass *analysis.Pass) {
+	fn := calledFunc(call, pass)
+	if fn == nil || fn.Pkg() == nil {
+		return
+	}
+
+	isLauncher := slices.ContainsFunc(goroutineLaunchers, func(launcher goroutineLauncher) bool {
+		return fn.Name() == launcher.method && methodRecvTypeName(fn) == launcher.typeName &&
+			(fn.Pkg().Path() == launcher.pkgPath || strings.HasSuffix(fn.Pkg().Path(), "/"+launcher.pkgPath))
+	})
+	if !isLauncher {
+		return
+	}
+
+	for _, arg := range call.Args {
+		reportSharedValues(arg, arg, pass)
+	}
+}
+
+// handleSendStmt reports transactions, cursors and collections obtained from a
+// transaction sent over a channel.
+func handleSendStmt(stmt *ast.SendStmt, pass *analysis.Pass) {
+	id, isIdent := unwrapParens(stmt.Value).(*ast.Ident)
+	if !isIdent {
+		return
+	}
+
+	if desc := describeSharedValue(pass.TypesInfo.Uses[id], pass); desc != "" {
+		pass.Reportf(stmt.Value.Pos(), msgSentOverChannel, desc)
+	}
+}
+
+// reportSharedValues reports the first use in root of each variable declared
+// outside scope that holds a value unsafe for concurrent use. Nested go
+// statements are left to their own visit.
+func reportSharedValues(root, scope ast.Node, pass *analysis.Pass) {
+	seen := make(map[types.Object]bool)
+
+	ast.Inspect(root, func(node ast.Node) bool {
+		switch typed := node.(type) {
+		case *ast.GoStmt:
+			return false
+		case *ast.Ident:
+			obj, isVar := pass.TypesInfo.Uses[typed].(*types.Var)
+			if !isVar || seen[obj] || (obj.Pos() >= scope.Pos() && obj.Pos() < scope.End()) {
+				return true
+			}
+
+			if desc := describeSharedValue(obj, pass); desc != "" {
+				seen[obj] = true
+
+				pass.Reportf(typed.Pos(), msgSharedWithGoroutine, desc)
+			}
+		}
+
+		return true
+	})
+}
+
+// describeSharedValue describes obj when it holds an arangodb.Transaction, a
+// Cursor, a CursorBatch or a Collection obtained from a transaction, and
+// returns an empty string otherwise.
+func describeSharedValue(obj types.Object, pass *analysis.Pass) string {
+	if obj == nil {
+		return ""
+	}
+
+	switch arangoTypeName(obj.Type()) {
+	case transactionTypeName:
+		return "transaction " + obj.Name()
+	case cursorTypeName, cursorBatchTypeName:
+		return "cursor " + obj.Name()
+	case collectionTypeName:
+		if trx := collectionTransaction(obj, pass); trx != "" {
+			return "collection " + obj.Name() + " of transaction " + trx
+		}
+	}
+
+	return ""
+}
+
+// collectionTransaction returns the name of the transaction a collection
+// variable was obtained from with GetCollection or Collection, or an empty
+// string.
+func collectionTransaction(obj types.Object, pass *analysis.Pass) string {
+	call, isCall := unwrapParens(localDefinitionValue(obj, pass)).(*ast.CallExpr)
+	if !isCall {
+		return ""
+	}
+
+	method := arangoMethod(call, pass)
+	if method == nil || (method.Name() != methodGetCollection && method.Name() != methodCollection) {
+		return ""
+	}
+
+	receiver, isIdent := unwrapParens(call.Fun.(*ast.SelectorExpr).X).(*ast.Ident)
+	if !isIdent || arangoTypeName(pass.TypesInfo.TypeOf(receiver)) != transactionTypeName {
+		return ""
+	}
+
+	return receiver.Name
+}
+
+// arangoTypeName returns the name of t when it is a named type declared in the
+// arangodb package, or an empty string.
+func arangoTypeName(t types.Type) string {
+	named, isNamed := t.(*types.Named)
+	if !isNamed || named.Obj().Pkg() == nil || !strings.HasSuffix(named.Obj().Pkg().Path(), arangoPackageSuffix) {
+		return ""
+	}
+
+	return named.Obj().Name()
+}
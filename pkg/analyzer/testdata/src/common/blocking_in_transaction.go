@@ -0,0 +1,61 @@
+package common
+
+import (
+	"context"
+	"net/http"
+	"time"
+
+	"github.com/arangodb/go-driver/v2/arangodb"
+)
+
+// callInventoryService is configured as a blocking call in the test settings.
+func callInventoryService() {}
+
+func blockingInTransaction(ctx context.Context, db arangodb.Database, client *http.Client, req *http.Request, events chan string) error {
+	opts := &arangodb.BeginTransactionOptions{AllowImplicit: false}
+
+	// Blocking work before the transaction is fine
+	time.Sleep(time.Millisecond)
+
+	trx, err := db.BeginTransaction(ctx, arangodb.TransactionCollections{}, opts)
+	if err != nil {
+		return err
+	}
+	defer trx.Abort(ctx, nil)
+
+	// Bad: blocking work while the transaction holds locks
+	time.Sleep(time.Second) // want `time.Sleep called while streaming transaction trx is open`
+	client.Do(req)          // want `\(\*net/http.Client\).Do called while streaming transaction trx is open`
+	<-events                // want `channel receive while streaming transaction trx is open`
+	callInventoryService()  // want `common.callInventoryService called while streaming transaction trx is open`
+
+	for range events { // want `channel receive while streaming transaction trx is open`
+		break
+	}
+
+	if err != nil {
+		trx.Abort(ctx, nil)
+
+		return err
+	}
+
+	// Good: goroutines do not block the transaction
+	go func() {
+		time.Sleep(time.Second)
+	}()
+
+	if err := trx.Commit(ctx, nil); err != nil {
+		return err
+	}
+
+	// Good: the transaction is committed
+	time.Sleep(time.Second)
+	<-events
+
+	return db.WithTransaction(ctx, arangodb.TransactionCollections{}, opts, nil, nil, func(ctx context.Context, tx arangodb.Transaction) error {
+		http.Get("http://inventory") // want `net/http.Get called while streaming transaction tx is open`
+		<-events                     // want `channel receive while streaming transaction tx is open`
+
+		return nil
+	})
+}
@@ -0,0 +1,86 @@
+package common
+
+import (
+	"context"
+	"encoding/json"
+
+	"github.com/arangodb/go-driver/v2/arangodb"
+)
+
+const userSchemaJSON = `{"type": "object", "properties": {"name": {"type": "string"}}, "required": ["name"]}`
+
+const brokenSchemaJSON = `{"type": "object", "properties": {"name": {"type": "string"}}`
+
+var ageSchema = map[string]any{"type": "integer", "minimum": 0, "exclusiveMinimum": 0}
+
+func collectionSchemas(ctx context.Context, db arangodb.Database) {
+	// Bad: invalid level
+	db.CreateCollection(ctx, "users", &arangodb.CreateCollectionProperties{
+		Schema: &arangodb.CollectionSchemaOptions{
+			Rule:  json.RawMessage(userSchemaJSON),
+			Level: "strcit", // want `invalid collection schema level "strcit"; expected none, new, moderate or strict`
+		},
+	})
+
+	// Bad: invalid rules
+	_ = arangodb.CollectionSchemaOptions{
+		Rule: map[string]any{ // want `invalid collection schema rule: rule.type has unknown type "text"`
+			"type": "text",
+		},
+	}
+	_ = arangodb.CollectionSchemaOptions{
+		Rule: map[string]any{ // want `invalid collection schema rule: rule.properties.age.exclusiveMinimum must be a boolean in JSON Schema draft-04`
+			"type":       "object",
+			"properties": map[string]any{"age": ageSchema},
+		},
+	}
+	_ = arangodb.CollectionSchemaOptions{
+		Rule: map[string]any{ // want `invalid collection schema rule: rule.required lists "name" twice`
+			"required": []string{"name", "name"},
+		},
+	}
+	_ = arangodb.CollectionSchemaOptions{
+		Rule: map[string]any{ // want `invalid collection schema rule: rule.const is not part of JSON Schema draft-04 and is ignored by ArangoDB`
+			"const": "fixed",
+		},
+	}
+	_ = arangodb.CollectionSchemaOptions{
+		Rule: map[string]any{ // want `invalid collection schema rule: rule.properties.tags.minItems must be a non-negative integer`
+			"properties": map[string]any{
+				"tags": map[string]any{"type": []string{"array", "null"}, "minItems": -1},
+			},
+		},
+	}
+	_ = arangodb.CollectionSchemaOptions{
+		Rule: "object", // want `invalid collection schema rule: the rule must be a JSON object`
+	}
+
+	var schema arangodb.CollectionSchemaOptions
+	schema.LoadRule([]byte(brokenSchemaJSON)) // want `invalid collection schema rule: malformed JSON: unexpected end of JSON input`
+	schema.LoadRule([]byte(`{"anyOf": []}`))  // want `invalid collection schema rule: rule.anyOf must be a non-empty array of schemas`
+
+	// Good: valid rules and levels
+	db.CreateCollection(ctx, "users", &arangodb.CreateCollectionProperties{
+		Schema: &arangodb.CollectionSchemaOptions{
+			Rule: map[string]any{
+				"type": "object",
+				"properties": map[string]any{
+					"name": map[string]any{"type": "string", "minLength": 1},
+					"age":  map[string]any{"type": "integer", "minimum": 0, "exclusiveMinimum": true},
+				},
+				"required":             []any{"name"},
+				"additionalProperties": false,
+			},
+			Level:   arangodb.CollectionSchemaLevelStrict,
+			Message: "invalid user",
+		},
+	})
+	schema.LoadRule([]byte(userSchemaJSON))
+
+	// Good: rules only known at runtime are not checked
+	schema.LoadRule(readSchema())
+}
+
+func readSchema() []byte {
+	return nil
+}
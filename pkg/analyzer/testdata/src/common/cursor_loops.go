@@ -0,0 +1,127 @@
+package common
+
+import (
+	"context"
+	"log"
+
+	"github.com/arangodb/go-driver/v2/arangodb"
+	"github.com/arangodb/go-driver/v2/arangodb/shared"
+)
+
+type cursorDoc struct {
+	Name string `json:"name"`
+}
+
+type cursorHolder struct {
+	cursor arangodb.Cursor
+}
+
+func validateCursorDoc(cursorDoc) error { return nil }
+
+func cursorLoops(cursor arangodb.Cursor, batch arangodb.CursorBatch, holder cursorHolder) error {
+	ctx := context.Background()
+
+	// Bad: end of cursor treated as a fatal error
+	for { // want "loop reads cursor cursor without checking HasMore or shared.IsNoMoreDocuments"
+		var doc cursorDoc
+		if _, err := cursor.ReadDocument(ctx, &doc); err != nil {
+			return err
+		}
+	}
+
+	// Bad: batch loop never consults HasMoreBatches
+	for { // want "loop reads cursor batch without checking HasMoreBatches or shared.IsNoMoreDocuments"
+		var docs []cursorDoc
+		if err := batch.ReadNextBatch(ctx, &docs); err != nil {
+			return err
+		}
+	}
+
+	// Bad: HasMore checked on another cursor
+	for holder.cursor.HasMore() { // want "loop reads cursor cursor without checking HasMore or shared.IsNoMoreDocuments"
+		var doc cursorDoc
+		_, _ = cursor.ReadDocument(ctx, &doc)
+	}
+
+	// Bad: shared.IsNoMoreDocuments checked on another error
+	for { // want "loop reads cursor cursor without checking HasMore or shared.IsNoMoreDocuments"
+		var doc cursorDoc
+		_, err := cursor.ReadDocument(ctx, &doc)
+
+		if otherErr := validateCursorDoc(doc); shared.IsNoMoreDocuments(otherErr) {
+			log.Print(otherErr)
+		}
+
+		if err != nil {
+			return err
+		}
+	}
+
+	// Bad: shared.IsNoMoreDocuments does not leave the loop
+	for { // want "loop reads cursor cursor without checking HasMore or shared.IsNoMoreDocuments"
+		var doc cursorDoc
+		_, err := cursor.ReadDocument(ctx, &doc)
+		if shared.IsNoMoreDocuments(err) {
+			log.Print("no more documents")
+		}
+	}
+
+	// Good: HasMore in the loop condition
+	for cursor.HasMore() {
+		var doc cursorDoc
+		if _, err := cursor.ReadDocument(ctx, &doc); err != nil {
+			return err
+		}
+	}
+
+	// Good: HasMore checked in the body
+	for {
+		if !holder.cursor.HasMore() {
+			break
+		}
+
+		var doc cursorDoc
+		if _, err := holder.cursor.ReadDocument(ctx, &doc); err != nil {
+			return err
+		}
+	}
+
+	// Good: end of cursor detected with shared.IsNoMoreDocuments
+	for {
+		var doc cursorDoc
+		_, err := cursor.ReadDocument(ctx, &doc)
+		if shared.IsNoMoreDocuments(err) {
+			break
+		} else if err != nil {
+			return err
+		}
+	}
+
+	// Good: end of cursor detected within the error branch
+	for {
+		var doc cursorDoc
+		if _, err := cursor.ReadDocument(ctx, &doc); err != nil {
+			if shared.IsNoMoreDocuments(err) {
+				break
+			}
+
+			return err
+		}
+	}
+
+	// Good: HasMoreBatches in the loop condition
+	for batch.HasMoreBatches() {
+		var docs []cursorDoc
+		if err := batch.ReadNextBatch(ctx, &docs); err != nil {
+			return err
+		}
+	}
+
+	// Good: bounded loop reading a known number of documents
+	for i := 0; i < 3 && cursor.HasMore(); i++ {
+		var doc cursorDoc
+		_, _ = cursor.ReadDocument(ctx, &doc)
+	}
+
+	return nil
+}
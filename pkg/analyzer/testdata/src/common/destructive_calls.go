@@ -0,0 +1,33 @@
+package common
+
+import (
+	"context"
+
+	"github.com/arangodb/go-driver/v2/arangodb"
+)
+
+func destructiveCalls(ctx context.Context, client arangodb.Client, db arangodb.Database, col arangodb.Collection, user arangodb.User) {
+	// Bad: data loss from service code
+	col.Truncate(ctx)                       // want `Collection.Truncate is a destructive administrative operation; call it from an approved package or annotate it with //arangolint:allow-destructive <reason>`
+	col.Remove(ctx)                         // want `Collection.Remove is a destructive administrative operation`
+	db.Remove(ctx)                          // want `Database.Remove is a destructive administrative operation`
+	client.BackupRestore(ctx, "backup")     // want `ClientAdminBackup.BackupRestore is a destructive administrative operation`
+	client.BackupDelete(ctx, "backup")      // want `ClientAdminBackup.BackupDelete is a destructive administrative operation`
+	user.SetDatabaseAccess(ctx, "db", "rw") // want `UserPermissions.SetDatabaseAccess is a destructive administrative operation`
+	user.RemoveDatabaseAccess(ctx, "db")    // want `UserPermissions.RemoveDatabaseAccess is a destructive administrative operation`
+	client.RemoveUser(ctx, "alice")         // want `ClientUsers.RemoveUser is a destructive administrative operation`
+
+	// Bad: the annotation needs a reason
+	//arangolint:allow-destructive
+	col.Truncate(ctx) // want `Collection.Truncate is a destructive administrative operation`
+
+	// Good: annotated with a reason
+	//arangolint:allow-destructive nightly cleanup of the scratch collection
+	col.Truncate(ctx)
+
+	col.Truncate(ctx) //arangolint:allow-destructive reset before import
+
+	// Good: read-only calls
+	col.Count(ctx)
+	user.GetDatabaseAccess(ctx, "db")
+}
@@ -0,0 +1,13 @@
+package common
+
+import (
+	"context"
+
+	"github.com/arangodb/go-driver/v2/arangodb"
+)
+
+// Good: tests may reset their fixtures
+func resetFixtures(ctx context.Context, db arangodb.Database, col arangodb.Collection) {
+	col.Truncate(ctx)
+	db.Remove(ctx)
+}
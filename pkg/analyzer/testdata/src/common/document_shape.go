@@ -0,0 +1,49 @@
+package common
+
+import (
+	"context"
+	"encoding/json"
+
+	"github.com/arangodb/go-driver/v2/arangodb"
+)
+
+type shapeDoc struct {
+	Name string `json:"name"`
+}
+
+// consumeReader stands for code handling the response reader of batch methods.
+func consumeReader(_ any, _ error) {}
+
+func documentShape[T any](coll arangodb.Collection, generic T, dynamic any) {
+	ctx := context.Background()
+
+	docs := []shapeDoc{{Name: "a"}}
+	docArray := [2]shapeDoc{}
+
+	// Bad: batch methods given a single document
+	consumeReader(coll.CreateDocuments(ctx, shapeDoc{}))                  // want `CreateDocuments expects a slice or array of documents, got common.shapeDoc`
+	consumeReader(coll.CreateDocumentsWithOptions(ctx, &shapeDoc{}, nil)) // want `CreateDocumentsWithOptions expects a slice or array of documents, got \*common.shapeDoc`
+	consumeReader(coll.ReplaceDocuments(ctx, map[string]shapeDoc{}))      // want `ReplaceDocuments expects a slice or array of documents, got map\[string\]common.shapeDoc`
+	consumeReader(coll.UpdateDocuments(ctx, nil))                         // want `UpdateDocuments expects a slice or array of documents, got untyped nil`
+	consumeReader(coll.ReadDocumentsWithOptions(ctx, "key", nil))         // want `ReadDocumentsWithOptions expects a slice or array of documents, got string`
+	consumeReader(coll.DeleteDocumentsWithOptions(ctx, shapeDoc{}, nil))  // want `DeleteDocumentsWithOptions expects a slice or array of documents, got common.shapeDoc`
+
+	// Bad: single-document methods given a slice
+	coll.CreateDocument(ctx, docs)                         // want `CreateDocument given a slice of documents; use CreateDocuments`
+	coll.UpdateDocumentWithOptions(ctx, "key", &docs, nil) // want `UpdateDocumentWithOptions given a slice of documents; use UpdateDocumentsWithOptions`
+	coll.ReplaceDocument(ctx, "key", docArray)             // want `ReplaceDocument given a slice of documents; use ReplaceDocuments`
+
+	// Good
+	consumeReader(coll.CreateDocuments(ctx, docs))
+	consumeReader(coll.CreateDocuments(ctx, &docs))
+	consumeReader(coll.CreateDocuments(ctx, docArray))
+	consumeReader(coll.ReadDocumentsWithOptions(ctx, []string{"a", "b"}, nil))
+	consumeReader(coll.DeleteDocumentsWithOptions(ctx, []string{"a", "b"}, nil))
+	coll.CreateDocument(ctx, shapeDoc{})
+	coll.CreateDocument(ctx, json.RawMessage(`{"name":"a"}`))
+
+	// Good: dynamic type unknown, stay conservative
+	consumeReader(coll.CreateDocuments(ctx, dynamic))
+	consumeReader(coll.CreateDocuments(ctx, generic))
+	coll.CreateDocument(ctx, dynamic)
+}
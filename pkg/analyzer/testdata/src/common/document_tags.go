@@ -0,0 +1,81 @@
+package common
+
+import (
+	"context"
+
+	"github.com/arangodb/go-driver/v2/arangodb"
+)
+
+type taggedMissingOmitempty struct {
+	Key  string `json:"_key"`
+	Rev  string `json:"_rev,omitempty"`
+	Name string `json:"name"`
+}
+
+type taggedVelocypack struct {
+	Key  string `json:"_key,omitempty" velocypack:"_key"`
+	Name string `json:"name" velocypack:"name"`
+}
+
+type taggedBothMissingOmitempty struct {
+	Key string `json:"_key" velocypack:"_key"`
+}
+
+type taggedDuplicate struct {
+	Key   string `json:"_key,omitempty"`
+	Other string `json:"_key,omitempty"`
+}
+
+type taggedWithID struct {
+	Key string `json:"_key,omitempty"`
+	ID  string `json:"_id,omitempty"`
+}
+
+type taggedEmbedded struct {
+	taggedMissingOmitempty
+	Age int `json:"age"`
+}
+
+type taggedDriverMeta struct {
+	arangodb.DocumentMeta
+	Name string `json:"name"`
+}
+
+type taggedShadowed struct {
+	arangodb.DocumentMeta
+	Key string `json:"_key,omitempty"`
+}
+
+type taggedValid struct {
+	Key  string `json:"_key,omitempty"`
+	Rev  string `json:"_rev,omitempty"`
+	Name string `json:"name"`
+	Skip string `json:"-"`
+}
+
+func documentTags(coll arangodb.Collection) {
+	ctx := context.Background()
+
+	// Bad: system attributes without omitempty
+	coll.CreateDocument(ctx, taggedMissingOmitempty{})                                    // want `field taggedMissingOmitempty.Key tags "_key" \(json\) without omitempty`
+	coll.ReplaceDocument(ctx, "key", &taggedMissingOmitempty{})                           // want `field taggedMissingOmitempty.Key tags "_key" \(json\) without omitempty`
+	consumeReader(coll.CreateDocuments(ctx, []taggedMissingOmitempty{}))                  // want `field taggedMissingOmitempty.Key tags "_key" \(json\) without omitempty`
+	consumeReader(coll.UpdateDocumentsWithOptions(ctx, []*taggedMissingOmitempty{}, nil)) // want `field taggedMissingOmitempty.Key tags "_key" \(json\) without omitempty`
+	coll.UpdateDocument(ctx, "key", taggedVelocypack{})                                   // want `field taggedVelocypack.Key tags "_key" \(velocypack\) without omitempty`
+	coll.CreateDocumentWithOptions(ctx, taggedEmbedded{}, nil)                            // want `field taggedMissingOmitempty.Key tags "_key" \(json\) without omitempty`
+	coll.CreateDocument(ctx, taggedBothMissingOmitempty{})                                // want `field taggedBothMissingOmitempty.Key tags "_key" \(json, velocypack\) without omitempty`
+
+	// Bad: several fields serialized as the same system attribute
+	consumeReader(coll.ReplaceDocuments(ctx, []taggedDuplicate{})) // want `fields taggedDuplicate.Key and taggedDuplicate.Other both tag "_key" \(json\)`
+
+	// Bad: _id is assigned by the server on insert
+	coll.CreateDocument(ctx, &taggedWithID{}) // want `field taggedWithID.ID tags "_id" \(json\) on insert; the server assigns document ids`
+
+	// Good
+	coll.UpdateDocument(ctx, "key", &taggedWithID{})
+	coll.CreateDocument(ctx, &taggedValid{})
+	consumeReader(coll.CreateDocuments(ctx, []taggedValid{}))
+	coll.CreateDocument(ctx, &taggedDriverMeta{})
+	coll.CreateDocument(ctx, &taggedShadowed{})
+	coll.CreateDocument(ctx, map[string]any{"_key": ""})
+}
@@ -0,0 +1,70 @@
+package common
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"strings"
+
+	"github.com/arangodb/go-driver/v2/arangodb"
+	"github.com/arangodb/go-driver/v2/arangodb/shared"
+)
+
+func errorMessageMatching(ctx context.Context, col arangodb.Collection, key string) {
+	var user map[string]any
+
+	_, err := col.ReadDocument(ctx, key, &user)
+
+	// Bad: matching the message of a driver error
+	if strings.Contains(err.Error(), "not found") { // want `driver error matched on its message; use shared.IsNotFound\(err\)`
+		return
+	}
+
+	if strings.Contains(strings.ToLower(err.Error()), "1202") { // want `driver error matched on its message; use shared.IsArangoErrorWithErrorNum\(err, shared.ErrArangoDocumentNotFound\)`
+		return
+	}
+
+	if err.Error() == "unique constraint violated" { // want `driver error matched on its message; use shared.IsArangoErrorWithErrorNum\(err, shared.ErrArangoUniqueConstraintViolated\)`
+		return
+	}
+
+	if strings.HasPrefix(err.Error(), "timeout") { // want `driver error matched on its message; use shared.IsArangoErrorWithCode or shared.IsArangoErrorWithErrorNum`
+		return
+	}
+
+	// Good: errors not returned by driver calls
+	other := errors.New("not found")
+	if strings.Contains(other.Error(), "not found") {
+		return
+	}
+}
+
+func errorCodeComparing(ctx context.Context, col arangodb.Collection, key string) {
+	var user map[string]any
+
+	_, err := col.ReadDocument(ctx, key, &user)
+
+	var arangoErr shared.ArangoError
+	if errors.As(err, &arangoErr) && arangoErr.Code == http.StatusForbidden { // want `ArangoError.Code compared with 403; use shared.IsForbidden\(err\)`
+		return
+	}
+
+	if errors.As(err, &arangoErr) && arangoErr.ErrorNum != 1202 { // want `ArangoError.ErrorNum compared with 1202; use shared.IsArangoErrorWithErrorNum\(err, shared.ErrArangoDocumentNotFound\)`
+		return
+	}
+
+	// Bad, without fix: the fix would leave arangoErr unused
+	if ok, arangoErr := shared.IsArangoError(err); ok && arangoErr.Code == 503 { // want `ArangoError.Code compared with 503; use shared.IsArangoErrorWithCode\(err, 503\)`
+		return
+	}
+
+	// Bad, without fix: shared.IsNotFound also matches errorNums 1202 and 1203
+	if err.(shared.ArangoError).Code == 404 { // want `ArangoError.Code compared with 404; use shared.IsNotFound\(err\)`
+		return
+	}
+
+	// Good
+	if shared.IsNotFound(err) || shared.IsArangoErrorWithErrorNum(err, shared.ErrArangoConflict) {
+		return
+	}
+}
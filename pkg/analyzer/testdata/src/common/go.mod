@@ -2,7 +2,10 @@ module common
 
 go 1.24.2
 
-require github.com/arangodb/go-driver/v2 v2.1.3
+require (
+	github.com/arangodb/go-driver/v2 v2.1.3
+	golang.org/x/sync v0.10.0
+)
 
 require (
 	github.com/arangodb/go-velocypack v0.0.0-20200318135517-5af53c29c67e // indirect
@@ -0,0 +1,35 @@
+package common
+
+import (
+	"os"
+
+	"github.com/arangodb/go-driver/v2/connection"
+)
+
+const serviceToken = "s3cr3t-token"
+
+var legacyPassword = "hunter2"
+
+func hardcodedCredentials() {
+	// Bad
+	connection.NewBasicAuth("root", "openSesame")                          // want `hardcoded password passed to connection.NewBasicAuth; load credentials from the environment or a secret store`
+	connection.NewJWTAuthWrapper("root", "openSesame")                     // want `hardcoded password passed to connection.NewJWTAuthWrapper`
+	connection.NewHeaderAuth("Authorization", "Bearer "+serviceToken)      // want `hardcoded value passed to connection.NewHeaderAuth`
+	connection.NewHeaderAuth("Authorization", "Bearer %s", "s3cr3t-token") // want `hardcoded value passed to connection.NewHeaderAuth`
+	connection.NewBasicAuth("root", legacyPassword)                        // want `hardcoded password passed to connection.NewBasicAuth`
+
+	password := "letMeIn"
+	connection.NewBasicAuth("root", password) // want `hardcoded password passed to connection.NewBasicAuth`
+
+	// Good: credentials come from the environment
+	connection.NewBasicAuth("root", os.Getenv("ARANGO_PASSWORD"))
+	connection.NewHeaderAuth("Authorization", "Bearer %s", os.Getenv("ARANGO_TOKEN"))
+
+	// Good: empty passwords are not secrets
+	connection.NewBasicAuth("root", "")
+
+	// Good: reassigned before use
+	secret := ""
+	secret = os.Getenv("ARANGO_PASSWORD")
+	connection.NewBasicAuth("root", secret)
+}
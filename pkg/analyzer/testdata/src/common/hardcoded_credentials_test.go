@@ -0,0 +1,8 @@
+package common
+
+import "github.com/arangodb/go-driver/v2/connection"
+
+// Good: tests may use fixed credentials
+func testAuthentication() connection.Authentication {
+	return connection.NewBasicAuth("root", "test-password")
+}
@@ -0,0 +1,57 @@
+package common
+
+import (
+	"context"
+
+	"github.com/arangodb/go-driver/v2/arangodb"
+)
+
+var geoFields = []string{"lat", "lng", "alt"}
+
+func indexDefinitions(ctx context.Context, col arangodb.Collection) {
+	// Bad: empty, duplicated or empty-named fields
+	col.EnsurePersistentIndex(ctx, []string{}, nil)                 // want `EnsurePersistentIndex has no fields`
+	col.EnsurePersistentIndex(ctx, []string{"email", "email"}, nil) // want `EnsurePersistentIndex lists field "email" twice`
+	col.EnsureMDIIndex(ctx, []string{"x", ""}, nil)                 // want `EnsureMDIIndex has an empty field name`
+
+	// Bad: geo indexes take one or two fields
+	col.EnsureGeoIndex(ctx, geoFields, nil) // want `EnsureGeoIndex takes one or two fields, got 3`
+
+	// Bad: TTL indexes take one field and a non-negative expireAfter
+	col.EnsureTTLIndex(ctx, []string{"createdAt", "updatedAt"}, 3600, nil) // want `EnsureTTLIndex takes exactly one field, got 2`
+	col.EnsureTTLIndex(ctx, []string{"createdAt"}, -1, nil)                // want `EnsureTTLIndex expireAfter must not be negative, got -1`
+
+	// Bad: prefix fields of MDI-prefixed indexes
+	col.EnsureMDIPrefixedIndex(ctx, []string{"x", "y"}, &arangodb.CreateMDIPrefixedIndexOptions{
+		PrefixFields: []string{"tenant", "tenant"}, // want `EnsureMDIPrefixedIndex PrefixFields lists field "tenant" twice`
+	})
+
+	// Bad: inverted indexes need fields
+	col.EnsureInvertedIndex(ctx, &arangodb.InvertedIndexOptions{Name: "search"}) // want `EnsureInvertedIndex has no fields`
+	col.EnsureInvertedIndex(ctx, &arangodb.InvertedIndexOptions{
+		Fields: []arangodb.InvertedIndexField{{Name: "title"}, {Name: "title"}}, // want `EnsureInvertedIndex lists field "title" twice`
+	})
+
+	// Bad: deprecated index type
+	col.EnsureZKDIndex(ctx, []string{"x", "y"}, nil) // want `EnsureZKDIndex is deprecated; use EnsureMDIIndex`
+
+	// Good: valid definitions
+	col.EnsurePersistentIndex(ctx, []string{"email"}, nil)
+	col.EnsureGeoIndex(ctx, []string{"lat", "lng"}, nil)
+	col.EnsureTTLIndex(ctx, []string{"createdAt"}, 0, nil)
+	col.EnsureMDIPrefixedIndex(ctx, []string{"x", "y"}, &arangodb.CreateMDIPrefixedIndexOptions{PrefixFields: []string{"tenant"}})
+	col.EnsureInvertedIndex(ctx, &arangodb.InvertedIndexOptions{
+		Fields: []arangodb.InvertedIndexField{{Name: "title"}, {Name: "body"}},
+	})
+
+	// Good: definitions only known at runtime are not checked
+	col.EnsurePersistentIndex(ctx, readFields(), nil)
+
+	opts := &arangodb.InvertedIndexOptions{Name: "search"}
+	opts.Fields = []arangodb.InvertedIndexField{{Name: "title"}}
+	col.EnsureInvertedIndex(ctx, opts)
+}
+
+func readFields() []string {
+	return nil
+}
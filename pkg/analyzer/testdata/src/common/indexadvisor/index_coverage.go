@@ -0,0 +1,67 @@
+package indexadvisor // want package:"indexDeclarations\\(sessions, users\\)"
+
+import (
+	"context"
+
+	"github.com/arangodb/go-driver/v2/arangodb"
+
+	"common/indexadvisor/schema"
+)
+
+const userSessionsQuery = `
+	FOR u IN users
+		FILTER u.email == @email
+		FOR s IN sessions
+			FILTER s.device == @device
+			RETURN s`
+
+const activeUsersQuery = "FOR u IN users FILTER u.status == @status SORT u.createdAt RETURN u"
+
+func indexCoverage(ctx context.Context, db arangodb.Database) error {
+	if err := schema.EnsureIndexes(ctx, db); err != nil {
+		return err
+	}
+
+	sessions, err := db.Collection(ctx, "sessions")
+	if err != nil {
+		return err
+	}
+
+	if _, _, err := sessions.EnsurePersistentIndex(ctx, []string{"userId"}, nil); err != nil {
+		return err
+	}
+
+	users, err := db.Collection(ctx, "users")
+	if err != nil {
+		return err
+	}
+
+	if _, _, err := users.EnsurePersistentIndex(ctx, []string{"nickname"}, nil); err != nil {
+		return err
+	}
+
+	// Bad: no declared index serves the filter or the sort
+	db.Query(ctx, "FOR u IN users FILTER u.name == @name RETURN u", nil)      // want `query filters collection "users" on name, which no declared index covers`
+	db.Query(ctx, "FOR u IN users FILTER u.createdAt > @since RETURN u", nil) // want `query filters collection "users" on createdAt, which no declared index covers`
+	db.Query(ctx, "FOR u IN users SORT u.name LIMIT 10 RETURN u", nil)        // want `query sorts collection "users" on name, which no declared index covers`
+
+	// Good: served by a declared index, a system attribute or a prefix of a persistent index
+	db.Query(ctx, "FOR u IN users FILTER u.email == @email RETURN u", nil)
+	db.Query(ctx, "FOR u IN users FILTER u.status == @status AND u.createdAt > @since RETURN u", nil)
+	db.Query(ctx, activeUsersQuery, nil)
+	db.Query(ctx, "FOR u IN users FILTER u._key IN @keys RETURN u", nil)
+	db.Query(ctx, "FOR u IN users FILTER u.nickname == @nickname RETURN u", nil)
+	db.Query(ctx, userSessionsQuery, nil)
+	db.Query(ctx, "FOR p IN places FILTER p.lng > 2 AND p.lat < 48 RETURN p", nil)
+
+	// Good: collections without declared indexes and search queries are not checked
+	db.Query(ctx, "FOR p IN products FILTER p.name == @name RETURN p", nil)
+
+	// Good: collections with indexes declared by this package only are not
+	// checked, as common/indexadvisor/migrations declares the others
+	db.Query(ctx, "FOR s IN sessions FILTER s.expiresAt < @now RETURN s", nil)
+	db.Query(ctx, "FOR s IN sessions FILTER s.expired == true REMOVE s IN sessions", nil)
+	db.Query(ctx, "FOR d IN usersView SEARCH d.name == @name RETURN d", nil)
+
+	return nil
+}
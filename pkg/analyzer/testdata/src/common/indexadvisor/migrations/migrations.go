@@ -0,0 +1,20 @@
+package migrations
+
+import (
+	"context"
+
+	"github.com/arangodb/go-driver/v2/arangodb"
+)
+
+// AddSessionExpiry declares an index the indexadvisor package never sees, as
+// it does not import this package.
+func AddSessionExpiry(ctx context.Context, db arangodb.Database) error {
+	sessions, err := db.Collection(ctx, "sessions")
+	if err != nil {
+		return err
+	}
+
+	_, _, err = sessions.EnsurePersistentIndex(ctx, []string{"expiresAt"}, nil)
+
+	return err
+}
@@ -0,0 +1,34 @@
+package schema
+
+import (
+	"context"
+
+	"github.com/arangodb/go-driver/v2/arangodb"
+)
+
+// EnsureIndexes declares the indexes of the users and places collections.
+func EnsureIndexes(ctx context.Context, db arangodb.Database) error {
+	users, err := db.Collection(ctx, "users")
+	if err != nil {
+		return err
+	}
+
+	if _, _, err := users.EnsurePersistentIndex(ctx, []string{"email"}, nil); err != nil {
+		return err
+	}
+
+	if _, _, err := users.EnsurePersistentIndex(ctx, []string{"status", "createdAt"}, nil); err != nil {
+		return err
+	}
+
+	places, err := db.Collection(ctx, "places")
+	if err != nil {
+		return err
+	}
+
+	_, _, err = places.EnsureMDIIndex(ctx, []string{"lat", "lng"}, &arangodb.CreateMDIIndexOptions{
+		FieldValueTypes: arangodb.MDIDoubleFieldType,
+	})
+
+	return err
+}
@@ -0,0 +1,46 @@
+package common
+
+import (
+	"crypto/tls"
+	"net/http"
+	"os"
+
+	"github.com/arangodb/go-driver/v2/connection"
+)
+
+const productionEndpoint = "http://db.internal:8529"
+
+var insecureTLS = &tls.Config{InsecureSkipVerify: true}
+
+func skipVerify(transport *http.Transport) {
+	transport.TLSClientConfig = &tls.Config{InsecureSkipVerify: true}
+}
+
+func insecureTransport() {
+	// Bad: TLS verification disabled
+	connection.WithHTTPTransport(connection.WithHTTPInsecureSkipVerify)   // want `connection.WithHTTPInsecureSkipVerify disables TLS certificate verification`
+	connection.WithHTTP2Transport(connection.WithHTTP2InsecureSkipVerify) // want `connection.WithHTTP2InsecureSkipVerify disables TLS certificate verification`
+	connection.WithHTTPTransport(skipVerify)                              // want `transport passed to connection.WithHTTPTransport sets InsecureSkipVerify; TLS certificates are not verified`
+	connection.WithHTTPTransport(func(transport *http.Transport) {        // want `transport passed to connection.WithHTTPTransport sets InsecureSkipVerify`
+		transport.TLSClientConfig = insecureTLS
+	})
+	connection.WithHTTPTransport(func(transport *http.Transport) { // want `transport passed to connection.WithHTTPTransport sets InsecureSkipVerify`
+		transport.TLSClientConfig.InsecureSkipVerify = true
+	})
+
+	// Bad: plain HTTP or malformed endpoints
+	connection.NewRoundRobinEndpoints([]string{"http://db-1:8529", "https://db-2:8529"}) // want `endpoint "http://db-1:8529" does not use TLS`
+	connection.NewEndpoints(productionEndpoint)                                          // want `endpoint "http://db.internal:8529" does not use TLS`
+	connection.NewEndpoints("db-1:8529")                                                 // want `malformed endpoint "db-1:8529": expected https://host\[:port\]`
+
+	endpoints := []string{"tcp://db-1:8529"} // want `endpoint "tcp://db-1:8529" does not use TLS`
+	connection.NewMaglevHashEndpoints(endpoints, nil)
+
+	// Good
+	connection.WithHTTPTransport(connection.DefaultHTTPTransportSettings, func(transport *http.Transport) {
+		transport.TLSClientConfig = &tls.Config{MinVersion: tls.VersionTLS12}
+	})
+	connection.NewRoundRobinEndpoints([]string{"https://db-1:8529", "https://db-2:8529"})
+	connection.NewEndpoints("ssl://db-1:8529")
+	connection.NewEndpoints(os.Getenv("ARANGO_ENDPOINT"))
+}
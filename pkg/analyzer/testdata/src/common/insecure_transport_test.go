@@ -0,0 +1,10 @@
+package common
+
+import "github.com/arangodb/go-driver/v2/connection"
+
+// Good: tests may talk to a local server over plain HTTP
+func localEndpoint() connection.Endpoint {
+	connection.WithHTTPTransport(connection.WithHTTPInsecureSkipVerify)
+
+	return connection.NewEndpoints("http://localhost:8529")
+}
@@ -0,0 +1,91 @@
+package common
+
+import (
+	"context"
+	"net/http"
+
+	"github.com/arangodb/go-driver/v2/arangodb"
+)
+
+const usersCollection = "users"
+
+func lookupsInLoops(client arangodb.Client, db arangodb.Database, names []string, tenants []string) {
+	ctx := context.Background()
+
+	// Bad: the same collection is resolved on every iteration
+	for range names {
+		db.GetCollection(ctx, usersCollection, nil) // want "GetCollection called in a loop with a loop-invariant name; resolve it once before the loop"
+	}
+
+	for i := 0; i < 3; i++ {
+		db.Collection(ctx, "users") // want "Collection called in a loop with a loop-invariant name; resolve it once before the loop"
+	}
+
+	for _, tenant := range tenants {
+		_ = tenant
+		client.GetDatabase(ctx, "shared", nil) // want "GetDatabase called in a loop with a loop-invariant name; resolve it once before the loop"
+	}
+
+	// Good: the name depends on the iteration
+	for _, name := range names {
+		db.GetCollection(ctx, name, nil)
+	}
+
+	for _, tenant := range tenants {
+		dbName := "tenant_" + tenant
+		client.GetDatabase(ctx, dbName, nil)
+	}
+
+	// Good: the name is computed inside the loop body
+	for {
+		name := nextCollectionName()
+		if name == "" {
+			break
+		}
+
+		db.GetCollection(ctx, name, nil)
+	}
+
+	cursor, _ := db.Query(ctx, "FOR j IN jobs RETURN j", nil)
+	for cursor.HasMore() {
+		var j lookupJob
+		cursor.ReadDocument(ctx, &j)
+		db.GetCollection(ctx, j.Coll, nil)
+	}
+
+	// Good: resolved once
+	coll, _ := db.GetCollection(ctx, usersCollection, nil)
+	for range names {
+		_ = coll
+	}
+}
+
+type lookupJob struct {
+	Coll string `json:"coll"`
+}
+
+func nextCollectionName() string { return "" }
+
+type lookupHandler struct {
+	db arangodb.Database
+}
+
+func (h lookupHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	// Bad: constant name resolved per request
+	h.db.GetCollection(r.Context(), usersCollection, nil) // want "GetCollection called in an HTTP handler with a constant name; resolve it once and reuse the handle"
+
+	// Good: the name comes from the request
+	h.db.GetCollection(r.Context(), r.URL.Query().Get("collection"), nil)
+}
+
+func registerLookupHandlers(mux *http.ServeMux, client arangodb.Client) {
+	mux.HandleFunc("/users", func(w http.ResponseWriter, r *http.Request) {
+		client.GetDatabase(r.Context(), "app", nil) // want "GetDatabase called in an HTTP handler with a constant name; resolve it once and reuse the handle"
+	})
+
+	// Good: resolved once when registering the handler
+	db, _ := client.GetDatabase(context.Background(), "app", nil)
+	mux.HandleFunc("/orders", func(w http.ResponseWriter, r *http.Request) {
+		_ = db
+	})
+}
@@ -0,0 +1,17 @@
+package migrations
+
+import (
+	"context"
+
+	"github.com/arangodb/go-driver/v2/arangodb"
+)
+
+// Good: migrations are allowed to drop collections
+func dropLegacyCollection(ctx context.Context, db arangodb.Database) error {
+	col, err := db.GetCollection(ctx, "legacy", nil)
+	if err != nil {
+		return err
+	}
+
+	return col.Remove(ctx)
+}
@@ -0,0 +1,70 @@
+package common
+
+import (
+	"context"
+
+	"github.com/arangodb/go-driver/v2/arangodb"
+)
+
+type loopDoc struct {
+	Key  string `json:"_key,omitempty"`
+	Name string `json:"name"`
+}
+
+func nPlusOne(db arangodb.Database, coll arangodb.Collection, cursor arangodb.Cursor, ids []string, docs []loopDoc) {
+	ctx := context.Background()
+
+	// Bad: one read per element
+	for _, id := range ids {
+		var doc loopDoc
+		coll.ReadDocument(ctx, id, &doc) // want "ReadDocument called in a loop with per-iteration arguments; use ReadDocuments"
+	}
+
+	// Bad: loop variable flows through a local variable
+	for i := 0; i < len(ids); i++ {
+		key := ids[i]
+		coll.DeleteDocument(ctx, key) // want "DeleteDocument called in a loop with per-iteration arguments; use DeleteDocuments"
+	}
+
+	// Bad: one write per element
+	for _, doc := range docs {
+		coll.CreateDocumentWithOptions(ctx, doc, nil) // want "CreateDocumentWithOptions called in a loop with per-iteration arguments; use CreateDocumentsWithOptions"
+		coll.UpdateDocument(ctx, doc.Key, doc)        // want "UpdateDocument called in a loop with per-iteration arguments; use UpdateDocuments"
+	}
+
+	// Bad: one query per element, even with bind variables
+	for _, id := range ids {
+		db.Query(ctx, "FOR u IN users FILTER u._key == @key RETURN u", &arangodb.QueryOptions{ // want "Query called in a loop with per-iteration arguments; use an AQL FOR over a bind array"
+			BindVars: map[string]any{"key": id},
+		})
+	}
+
+	// Bad: nested loops, outer loop variable
+	for _, id := range ids {
+		for range 2 {
+			coll.ReplaceDocument(ctx, id, loopDoc{}) // want "ReplaceDocument called in a loop with per-iteration arguments; use ReplaceDocuments"
+		}
+	}
+
+	// Good: batch method outside of the loop
+	var results []loopDoc
+	consumeReader(coll.ReadDocuments(ctx, ids))
+
+	// Good: AQL FOR over a bind array
+	db.Query(ctx, "FOR u IN users FILTER u._key IN @keys RETURN u", &arangodb.QueryOptions{
+		BindVars: map[string]any{"keys": ids},
+	})
+
+	// Good: arguments do not depend on the loop
+	for range ids {
+		var doc loopDoc
+		coll.ReadDocument(ctx, "settings", &doc)
+	}
+
+	// Good: cursor reads are not server round trips per element
+	for cursor.HasMore() {
+		var doc loopDoc
+		cursor.ReadDocument(ctx, &doc)
+		results = append(results, doc)
+	}
+}
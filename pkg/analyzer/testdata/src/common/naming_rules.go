@@ -0,0 +1,56 @@
+package common
+
+import (
+	"context"
+
+	"github.com/arangodb/go-driver/v2/arangodb"
+)
+
+const ordersCollection = "orders-2024"
+
+const badCollection = "orders 2024"
+
+func namingRules(ctx context.Context, client arangodb.Client, db arangodb.Database, graph arangodb.Graph) {
+	// Bad: invalid characters, leading digit or underscore, empty and too long names
+	db.CreateCollection(ctx, "user profiles", nil)                                                           // want `invalid collection name "user profiles": contains ' '; only letters, digits, underscores and dashes are allowed`
+	db.CreateCollection(ctx, "_audit", nil)                                                                  // want `invalid collection name "_audit": must start with a letter; only system collections start with an underscore`
+	db.GetCollection(ctx, "2024orders", nil)                                                                 // want `invalid collection name "2024orders": must start with a letter`
+	db.GetCollection(ctx, badCollection, nil)                                                                // want `invalid collection name "orders 2024": contains ' '`
+	db.CollectionExists(ctx, "")                                                                             // want `invalid collection name "": must not be empty`
+	client.CreateDatabase(ctx, "_reporting", nil)                                                            // want `invalid database name "_reporting": must start with a letter`
+	client.GetDatabase(ctx, "reporting.eu", nil)                                                             // want `invalid database name "reporting.eu": contains '.'`
+	client.CreateDatabase(ctx, "reporting_database_for_the_european_union_customers_and_partners_2024", nil) // want `invalid database name "reporting_database_for_the_european_union_customers_and_partners_2024": must be at most 64 bytes long`
+	db.CreateArangoSearchView(ctx, "search/products", nil)                                                   // want `invalid view name "search/products": contains '/'`
+	db.CreateGraph(ctx, "social graph", nil, nil)                                                            // want `invalid graph name "social graph": contains ' '`
+	db.EnsureCreatedAnalyzer(ctx, &arangodb.AnalyzerDefinition{Name: "text en"})                             // want `invalid analyzer name "text en": contains ' '`
+	db.BeginTransaction(ctx, arangodb.TransactionCollections{
+		Read:  []string{"users", "user.sessions"}, // want `invalid collection name "user.sessions": contains '.'`
+		Write: []string{"orders$"},                // want `invalid collection name "orders\$": contains '\$'`
+	}, &arangodb.BeginTransactionOptions{AllowImplicit: true})
+	db.CreateGraph(ctx, "social", &arangodb.GraphDefinition{
+		EdgeDefinitions: []arangodb.EdgeDefinition{{
+			Collection: "knows",
+			From:       []string{"people"},
+			To:         []string{"people", "pets!"}, // want `invalid collection name "pets!": contains '!'`
+		}},
+		OrphanCollections: []string{"-orphans"}, // want `invalid collection name "-orphans": must start with a letter`
+	}, nil)
+	graph.CreateEdgeDefinition(ctx, "follows", []string{"people"}, []string{"pages#1"}, nil) // want `invalid collection name "pages#1": contains '#'`
+
+	// Good: valid names, system collections and the _system database
+	db.CreateCollection(ctx, ordersCollection, nil)
+	db.CreateCollection(ctx, "_audit", &arangodb.CreateCollectionProperties{IsSystem: true})
+	db.GetCollection(ctx, "_users", nil)
+	client.GetDatabase(ctx, "_system", nil)
+	db.CreateArangoSearchView(ctx, "products_search", nil)
+	db.EnsureCreatedAnalyzer(ctx, &arangodb.AnalyzerDefinition{Name: "text_en"})
+	graph.CreateEdgeDefinition(ctx, "follows", []string{"people"}, []string{"people", "pages"}, nil)
+
+	// Good: names only known at runtime are not checked
+	name := readName()
+	db.CreateCollection(ctx, name, nil)
+}
+
+func readName() string {
+	return "runtime"
+}
@@ -0,0 +1,60 @@
+package common
+
+import (
+	"context"
+
+	"github.com/arangodb/go-driver/v2/arangodb"
+)
+
+func nestedTransactionHelper(ctx context.Context, db arangodb.Database) error {
+	trx, err := db.BeginTransaction(ctx, arangodb.TransactionCollections{Write: []string{"audit"}}, &arangodb.BeginTransactionOptions{AllowImplicit: false})
+	if err != nil {
+		return err
+	}
+
+	return trx.Commit(ctx, nil)
+}
+
+func nestedTransactionIndirect(ctx context.Context, db arangodb.Database) error {
+	return nestedTransactionHelper(ctx, db)
+}
+
+func nestedTransactions(ctx context.Context, db arangodb.Database) error {
+	opts := &arangodb.BeginTransactionOptions{AllowImplicit: false}
+	cols := arangodb.TransactionCollections{Write: []string{"users"}, Read: []string{"audit"}}
+
+	trx, err := db.BeginTransaction(ctx, cols, opts)
+	if err != nil {
+		return err
+	}
+
+	// Bad: both transactions write to users
+	db.BeginTransaction(ctx, arangodb.TransactionCollections{Exclusive: []string{"users"}}, opts) // want "nested transaction started while transaction trx is open; write collections overlap: users"
+
+	// Bad: the inner transaction only touches other collections
+	db.BeginTransaction(ctx, arangodb.TransactionCollections{Write: []string{"orders"}}, opts) // want "nested transaction started while transaction trx is open; no overlapping write collections"
+
+	// Bad: nested through package helpers; audit is read by the outer transaction
+	nestedTransactionIndirect(ctx, db) // want "nestedTransactionIndirect starts a nested transaction while transaction trx is open; write collections overlap: audit"
+
+	// Bad: collections are not known statically
+	db.WithTransaction(ctx, arangodb.TransactionCollections{Write: []string{collectionName()}}, opts, nil, nil, func(ctx context.Context, t arangodb.Transaction) error { // want "nested transaction started while transaction trx is open; collection overlap unknown"
+		return nil
+	})
+
+	if err := trx.Commit(ctx, nil); err != nil {
+		return err
+	}
+
+	// Good: the first transaction is committed
+	db.BeginTransaction(ctx, cols, opts)
+
+	return db.WithTransaction(ctx, cols, opts, nil, nil, func(ctx context.Context, t arangodb.Transaction) error {
+		// Bad: inside a WithTransaction callback
+		return nestedTransactionHelper(ctx, db) // want "nestedTransactionHelper starts a nested transaction while transaction t is open; write collections overlap: audit"
+	})
+}
+
+func collectionName() string {
+	return "users"
+}
@@ -0,0 +1,77 @@
+package common
+
+import (
+	"net/http"
+
+	"github.com/arangodb/go-driver/v2/arangodb"
+	"github.com/arangodb/go-driver/v2/connection"
+)
+
+// Good: built once at package init
+var sharedClient = arangodb.NewClient(connection.NewHttpConnection(connection.HttpConfiguration{}))
+
+type clientService struct {
+	client arangodb.Client
+}
+
+// Good: built once in a constructor
+func newClientService() *clientService {
+	conn := connection.NewHttp2Connection(connection.Http2Configuration{})
+
+	return &clientService{client: arangodb.NewClient(conn)}
+}
+
+func newPerRequestClient() arangodb.Client {
+	conn := connection.NewHttpConnection(connection.HttpConfiguration{}) // want "connection.NewHttpConnection called in newPerRequestClient, reachable from an HTTP handler; create the client once and reuse it"
+
+	return arangodb.NewClient(conn) // want "arangodb.NewClient called in newPerRequestClient, reachable from an HTTP handler; create the client once and reuse it"
+}
+
+type clientHandler struct{}
+
+func (clientHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	// Bad: new client per request
+	client := arangodb.NewClient(nil) // want "arangodb.NewClient called in an HTTP handler; create the client once and reuse it"
+	_ = client
+}
+
+func clientHandlerFunc(w http.ResponseWriter, r *http.Request) {
+	// Bad: new client per request through a helper
+	_ = newPerRequestClient()
+
+	perIterationPool()
+}
+
+func perIterationPool() {
+	factory := func() (connection.Connection, error) { return nil, nil }
+
+	for range 3 {
+		// Bad: new pool per iteration
+		connection.NewPool(2, factory) // want "connection.NewPool called in a loop; create the client once and reuse it"
+	}
+}
+
+// Good: one connection per configured endpoint, built at startup
+func init() {
+	connectEndpoints([]string{"http://db-1:8529", "http://db-2:8529"})
+}
+
+func connectEndpoints(endpoints []string) []arangodb.Client {
+	clients := make([]arangodb.Client, 0, len(endpoints))
+
+	for range endpoints {
+		clients = append(clients, arangodb.NewClient(connection.NewHttpConnection(connection.HttpConfiguration{})))
+	}
+
+	return clients
+}
+
+func perRequestRoutes(mux *http.ServeMux) {
+	service := newClientService()
+
+	mux.HandleFunc("/", func(w http.ResponseWriter, r *http.Request) {
+		// Good: the client is shared
+		_ = service.client
+		_ = sharedClient
+	})
+}
@@ -0,0 +1,74 @@
+package common
+
+import (
+	"context"
+
+	"github.com/arangodb/go-driver/v2/arangodb"
+)
+
+type address struct {
+	City string `json:"city"`
+}
+
+type auditInfo struct {
+	CreatedBy string `json:"createdBy"`
+}
+
+type account struct {
+	auditInfo
+
+	Key      string         `json:"_key,omitempty"`
+	Email    string         `json:"email"`
+	Name     string         `json:"name"`
+	Address  address        `json:"address"`
+	Settings map[string]any `json:"settings"`
+	Internal string         `json:"-"`
+	Age      int
+}
+
+type accountSummary struct {
+	Name string `json:"name"`
+	Mail string `json:"mail"`
+}
+
+func queryAttributes(ctx context.Context, db arangodb.Database) {
+	// Bad: typo'd attribute of the returned document
+	cursor, _ := db.Query(ctx, "FOR u IN accounts FILTER u.emial == @email RETURN u", nil) // want `attribute u.emial does not match any json field of account`
+
+	var user account
+	cursor.ReadDocument(ctx, &user)
+
+	// Bad: nested attribute and attribute excluded from json
+	nested, _ := db.Query(ctx, "FOR u IN accounts FILTER u.address.zip == @zip SORT u.Internal RETURN u", nil) // want `attribute u.address.zip does not match any json field of account` `attribute u.Internal does not match any json field of account`
+	nested.ReadDocument(ctx, &user)
+
+	// Bad: attribute names are case-sensitive, and untagged fields keep their Go name
+	cased, _ := db.Query(ctx, "FOR u IN accounts FILTER u.NAME == @name AND u.age > 18 RETURN u", nil) // want `attribute u.NAME does not match any json field of account` `attribute u.age does not match any json field of account`
+	cased.ReadDocument(ctx, &user)
+
+	// Bad: projection keys not matching the struct
+	projection, _ := db.Query(ctx, "FOR u IN accounts RETURN {name: u.name, email: u.email}", nil) // want `RETURN key "email" does not match any json field of accountSummary`
+
+	var summary accountSummary
+	projection.ReadDocument(ctx, &summary)
+
+	// Good: json names, Go names of untagged fields, embedded structs, maps and system attributes
+	valid, _ := db.Query(ctx, `
+		FOR u IN accounts
+			FILTER u.email == @email AND u.address.city == @city AND u.Age > 18
+			FILTER u.createdBy == @admin AND u.settings.theme == "dark" AND u._key != @self
+			RETURN u`, nil)
+	valid.ReadDocument(ctx, &user)
+
+	// Good: projection keys are decoded case-insensitively
+	valid, _ = db.Query(ctx, "FOR u IN accounts RETURN {Name: u.name, mail: u.email}", nil)
+	valid.ReadDocument(ctx, &summary)
+
+	// Good: results that are not a loop variable or an object literal are not checked
+	merged, _ := db.Query(ctx, "FOR u IN accounts RETURN MERGE(u, {extra: 1})", nil)
+	merged.ReadDocument(ctx, &summary)
+
+	var anything map[string]any
+	untyped, _ := db.Query(ctx, "FOR u IN accounts FILTER u.emial == @email RETURN u", nil)
+	untyped.ReadDocument(ctx, &anything)
+}
@@ -124,7 +124,7 @@ func queryInjectionWithControlFlow(db arangodb.Database, userName string, includ
 	filters := []string{userName, "john", "jane"}
 	for _, name := range filters {
 		loopQuery := "FOR u IN users FILTER u.name == '" + name + "' RETURN u"
-		db.Query(ctx, loopQuery, nil) // want "query string uses concatenation instead of bind variables"
+		db.Query(ctx, loopQuery, nil) // want "query string uses concatenation instead of bind variables" "Query called in a loop with per-iteration arguments; use an AQL FOR over a bind array"
 	}
 
 	// SAFE: Using bind vars with control flow
@@ -146,7 +146,7 @@ func queryInjectionWithControlFlow(db arangodb.Database, userName string, includ
 
 	// SAFE: Building query in loop with bind vars
 	for _, name := range filters {
-		db.Query(ctx, "FOR u IN users FILTER u.name == @name RETURN u", &arangodb.QueryOptions{
+		db.Query(ctx, "FOR u IN users FILTER u.name == @name RETURN u", &arangodb.QueryOptions{ // want "Query called in a loop with per-iteration arguments; use an AQL FOR over a bind array"
 			BindVars: map[string]interface{}{
 				"name": name,
 			},
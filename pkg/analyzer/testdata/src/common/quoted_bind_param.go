@@ -0,0 +1,33 @@
+package common
+
+import (
+	"context"
+
+	"github.com/arangodb/go-driver/v2/arangodb"
+)
+
+const quotedNameQuery = "FOR u IN users FILTER u.name == '@name' RETURN u"
+
+const escapedQuotedQuery = "FOR u IN users FILTER u.name == \"@name\" RETURN u"
+
+func quotedBindParams(ctx context.Context, db arangodb.Database, trx arangodb.Transaction, name string) {
+	// Bad: the parameter is compared as a literal string
+	db.Query(ctx, "FOR u IN users FILTER u.name == '@name' RETURN u", nil)              // want `bind parameter @name is inside an AQL string literal; the string is compared as is`
+	trx.Query(ctx, `FOR u IN users FILTER u.email == "@email" RETURN u`, nil)           // want `bind parameter @email is inside an AQL string literal`
+	db.QueryBatch(ctx, "FOR d IN sessions FILTER d.user == '@user' RETURN d", nil, nil) // want `bind parameter @user is inside an AQL string literal`
+	db.ValidateQuery(ctx, "FOR u IN users FILTER u.role IN ['@role'] RETURN u")         // want `bind parameter @role is inside an AQL string literal`
+	db.Query(ctx, quotedNameQuery, nil)                                                 // want `bind parameter @name is inside an AQL string literal`
+	db.Query(ctx, "RETURN CONCAT('Hello @name, welcome')", nil)                         // want `bind parameter @name is inside an AQL string literal`
+	db.Query(ctx, escapedQuotedQuery, nil)                                              // want `bind parameter @name is inside an AQL string literal`
+	db.Query(ctx, "FOR u IN users FILTER u.name == '"+"@name' RETURN u", nil)           // want `bind parameter @name is inside an AQL string literal`
+
+	// Good: bind parameters outside of strings, and strings merely containing @
+	db.Query(ctx, "FOR u IN users FILTER u.name == @name RETURN u", nil)
+	db.Query(ctx, "FOR u IN users FILTER u.email == 'admin@example.com' RETURN u", nil)
+	db.Query(ctx, "FOR u IN users FILTER LIKE(u.email, '%@example.com') RETURN u", nil)
+	db.Query(ctx, "FOR u IN users FILTER u.handle == '@' RETURN u", nil)
+	db.Query(ctx, "FOR u IN users FILTER u.name == @name /* not '@name' */ RETURN u", nil)
+
+	// Good: not a constant query
+	db.Query(ctx, "FOR u IN users FILTER u.name == '"+name+"' RETURN u", nil) // want `query string uses concatenation instead of bind variables`
+}
@@ -0,0 +1,57 @@
+package requiredfields
+
+import (
+	"context"
+
+	"github.com/arangodb/go-driver/v2/arangodb" // want `-required-fields entry CollectionDocumentCreateOptions.WaitForSync does not name a field of the arangodb package; it checks nothing` `-required-fields entry QueryOption.MemoryLimit does not name a field of the arangodb package`
+)
+
+var defaultQueryOptions = &arangodb.QueryOptions{
+	MemoryLimit: 1 << 20,
+	Options:     arangodb.QuerySubOptions{MaxRuntime: 30},
+}
+
+func queryOptions() *arangodb.QueryOptions { return nil }
+
+func requiredQueryFields(ctx context.Context, db arangodb.Database) {
+	// Bad
+	db.Query(ctx, "RETURN 1", nil)                                       // want `missing Options.MaxRuntime option` `missing MemoryLimit option`
+	db.Query(ctx, "RETURN 1", &arangodb.QueryOptions{MemoryLimit: 1024}) // want `missing Options.MaxRuntime option`
+
+	opts := arangodb.QueryOptions{Options: arangodb.QuerySubOptions{MaxRuntime: 10}}
+	db.Query(ctx, "RETURN 1", &opts) // want `missing MemoryLimit option`
+
+	// Good
+	opts.MemoryLimit = 1024
+	db.Query(ctx, "RETURN 1", &opts)
+	db.Query(ctx, "RETURN 1", defaultQueryOptions)
+	db.Query(ctx, "RETURN 1", queryOptions())
+
+	other := &arangodb.QueryOptions{MemoryLimit: 1024}
+	other.Options.MaxRuntime = 10
+	db.Query(ctx, "RETURN 1", other)
+
+	sub := arangodb.QuerySubOptions{MaxRuntime: 10}
+	db.Query(ctx, "RETURN 1", &arangodb.QueryOptions{MemoryLimit: 1024, Options: sub})
+}
+
+func requiredTransactionFields(ctx context.Context, db arangodb.Database) {
+	// Bad
+	db.BeginTransaction(ctx, arangodb.TransactionCollections{}, &arangodb.BeginTransactionOptions{AllowImplicit: true}) // want `missing LockTimeout option`
+	db.BeginTransaction(ctx, arangodb.TransactionCollections{}, nil)                                                    // want `missing AllowImplicit option` `missing LockTimeout option`
+
+	// Good
+	opts := &arangodb.BeginTransactionOptions{AllowImplicit: true}
+	opts.LockTimeout = 5
+	db.BeginTransaction(ctx, arangodb.TransactionCollections{}, opts)
+}
+
+func requiredCreateFields(ctx context.Context, col arangodb.Collection, doc any) {
+	waitForSync := true
+
+	// Bad
+	col.CreateDocumentWithOptions(ctx, doc, &arangodb.CollectionDocumentCreateOptions{}) // want `writes must set WithWaitForSync explicitly`
+
+	// Good
+	col.CreateDocumentWithOptions(ctx, doc, &arangodb.CollectionDocumentCreateOptions{WithWaitForSync: &waitForSync})
+}
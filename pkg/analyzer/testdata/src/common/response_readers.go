@@ -0,0 +1,110 @@
+package common
+
+import (
+	"context"
+	"errors"
+
+	"github.com/arangodb/go-driver/v2/arangodb"
+	"github.com/arangodb/go-driver/v2/arangodb/shared"
+)
+
+type readerDoc struct {
+	Name string `json:"name"`
+}
+
+func drainCreateReader(reader arangodb.CollectionDocumentCreateResponseReader) error {
+	for {
+		if _, err := reader.Read(); shared.IsNoMoreDocuments(err) {
+			return nil
+		} else if err != nil {
+			return err
+		}
+	}
+}
+
+func responseReaders(coll arangodb.Collection, docs []readerDoc, keys []string, flag bool) error {
+	ctx := context.Background()
+
+	// Bad: reader discarded
+	coll.CreateDocuments(ctx, docs)            // want "response reader returned by CreateDocuments is discarded; per-document errors are only reported by Read"
+	_, _ = coll.UpdateDocuments(ctx, docs)     // want "response reader returned by UpdateDocuments is discarded; per-document errors are only reported by Read"
+	_, err := coll.ReplaceDocuments(ctx, docs) // want "response reader returned by ReplaceDocuments is discarded; per-document errors are only reported by Read"
+	if err != nil {
+		return err
+	}
+
+	// Bad: reader read only once
+	deleted, err := coll.DeleteDocuments(ctx, keys) // want "response reader returned by DeleteDocuments is not read until shared.IsNoMoreDocuments"
+	if err != nil {
+		return err
+	}
+
+	if _, err := deleted.Read(nil); err != nil {
+		return err
+	}
+
+	// Bad: early return skips the drain loop
+	read, err := coll.ReadDocuments(ctx, keys) // want "response reader returned by ReadDocuments is not drained on every path"
+	if err != nil {
+		return err
+	}
+
+	if flag {
+		return errors.New("early")
+	}
+
+	for {
+		var doc readerDoc
+
+		_, err := read.Read(&doc)
+		if shared.IsNoMoreDocuments(err) {
+			break
+		}
+
+		if err != nil {
+			return err
+		}
+	}
+
+	// Good: drained after the error check
+	created, err := coll.CreateDocuments(ctx, docs)
+	if err != nil {
+		return err
+	}
+
+	for {
+		_, err := created.Read()
+		if shared.IsNoMoreDocuments(err) {
+			break
+		}
+
+		if err != nil {
+			return err
+		}
+	}
+
+	// Good: handed to a helper
+	created2, err := coll.CreateDocuments(ctx, docs)
+	if err != nil {
+		return err
+	}
+
+	if err := drainCreateReader(created2); err != nil {
+		return err
+	}
+
+	// Good: passed straight through
+	return drainCreateResult(coll.CreateDocuments(ctx, docs))
+}
+
+func drainCreateResult(reader arangodb.CollectionDocumentCreateResponseReader, err error) error {
+	if err != nil {
+		return err
+	}
+
+	return drainCreateReader(reader)
+}
+
+func responseReaderReturned(coll arangodb.Collection, docs []readerDoc) (arangodb.CollectionDocumentCreateResponseReader, error) {
+	return coll.CreateDocuments(context.Background(), docs)
+}
@@ -0,0 +1,49 @@
+package common
+
+import (
+	"context"
+
+	"github.com/arangodb/go-driver/v2/arangodb"
+)
+
+type resultUser struct {
+	Name string `json:"name"`
+}
+
+func resultArguments(db arangodb.Database, cursor arangodb.Cursor, batch arangodb.CursorBatch) {
+	ctx := context.Background()
+	coll, _ := db.GetCollection(ctx, "users", nil)
+
+	var user resultUser
+	var users []resultUser
+	var anything any = &user
+
+	// Bad: struct passed by value, decoded into a copy
+	cursor.ReadDocument(ctx, user)                       // want "call of ReadDocument passes non-pointer as result argument"
+	coll.ReadDocument(ctx, "key", user)                  // want "call of ReadDocument passes non-pointer as result argument"
+	coll.ReadDocumentWithOptions(ctx, "key", users, nil) // want "call of ReadDocumentWithOptions passes non-pointer as result argument"
+
+	// Bad: nil interface cannot be decoded into
+	cursor.ReadDocument(ctx, nil)      // want "call of ReadDocument passes nil as result argument"
+	coll.ReadDocument(ctx, "key", nil) // want "call of ReadDocument passes nil as result argument"
+
+	// Bad: batch reads require a pointer to a slice
+	batch.ReadNextBatch(ctx, &user)                           // want "call of ReadNextBatch passes non-slice pointer as result argument"
+	batch.RetryReadBatch(ctx, users)                          // want "call of RetryReadBatch passes non-pointer as result argument"
+	db.QueryBatch(ctx, "FOR u IN users RETURN u", nil, &user) // want "call of QueryBatch passes non-slice pointer as result argument"
+
+	// Good
+	cursor.ReadDocument(ctx, &user)
+	coll.ReadDocument(ctx, "key", &user)
+	coll.ReadDocumentWithOptions(ctx, "key", &users, nil)
+	batch.ReadNextBatch(ctx, &users)
+	batch.RetryReadBatch(ctx, &users)
+	db.QueryBatch(ctx, "FOR u IN users RETURN u", nil, &users)
+
+	// Good: QueryBatch skips decoding the first batch when result is nil
+	db.QueryBatch(ctx, "FOR u IN users RETURN u", nil, nil)
+
+	// Good: dynamic type unknown, stay conservative
+	cursor.ReadDocument(ctx, anything)
+	batch.ReadNextBatch(ctx, anything)
+}
@@ -0,0 +1,51 @@
+package common
+
+import (
+	"context"
+	"net/http"
+
+	"github.com/arangodb/go-driver/v2/arangodb"
+)
+
+func rootContextWithParam(ctx context.Context, db arangodb.Database) {
+	// Bad: drops the caller's cancellation and deadline
+	db.GetCollection(context.Background(), "users", nil)     // want `GetCollection called with context.Background\(\); use the in-scope context ctx`
+	db.Query(context.TODO(), "FOR u IN users RETURN u", nil) // want `Query called with context.TODO\(\); use the in-scope context ctx`
+
+	// Bad: closures see the enclosing function's context too
+	func() {
+		db.Info(context.Background()) // want `Info called with context.Background\(\); use the in-scope context ctx`
+	}()
+
+	// Good
+	db.GetCollection(ctx, "users", nil)
+}
+
+func rootContextWithRequest(w http.ResponseWriter, r *http.Request, db arangodb.Database) {
+	// Bad: the request carries the context
+	db.Info(context.Background()) // want `Info called with context.Background\(\); use the in-scope context r.Context\(\)`
+
+	// Good
+	db.Info(r.Context())
+
+	// Good: detached background work must not be cancelled with the request
+	go func() {
+		db.Info(context.Background())
+	}()
+
+	go db.Info(context.Background())
+}
+
+func rootContextShadowed(ctx context.Context, db arangodb.Database) {
+	for _, ctx := range []string{"a"} {
+		_ = ctx
+
+		// Good: the context parameter is shadowed, no safe replacement
+		db.Info(context.Background())
+	}
+}
+
+func rootContextWithoutScope(db arangodb.Database) {
+	// Good: no request-scoped context available
+	db.Info(context.Background())
+}
@@ -0,0 +1,13 @@
+package common
+
+import (
+	"context"
+	"net/http"
+
+	"github.com/arangodb/go-driver/v2/arangodb"
+)
+
+func rootContextLastImportUse(w http.ResponseWriter, r *http.Request, db arangodb.Database) {
+	// Bad: the fix also removes the context import, which is no longer used
+	db.Info(context.Background()) // want `Info called with context.Background\(\); use the in-scope context r.Context\(\)`
+}
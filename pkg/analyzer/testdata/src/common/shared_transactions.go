@@ -0,0 +1,105 @@
+package common
+
+import (
+	"context"
+
+	"github.com/arangodb/go-driver/v2/arangodb"
+	"golang.org/x/sync/errgroup"
+)
+
+func processCursor(arangodb.Cursor) {}
+
+func sharedTransactionGoroutines(ctx context.Context, db arangodb.Database, ids []string) error {
+	trx, err := db.BeginTransaction(ctx, arangodb.TransactionCollections{}, &arangodb.BeginTransactionOptions{AllowImplicit: true})
+	if err != nil {
+		return err
+	}
+	defer trx.Abort(ctx, nil)
+
+	col, err := trx.GetCollection(ctx, "users", nil)
+	if err != nil {
+		return err
+	}
+
+	// Bad: the transaction is captured by a goroutine
+	go func() {
+		consumeReader(trx.Query(ctx, "FOR u IN users RETURN u", nil)) // want `transaction trx is used from another goroutine; it does not support concurrent requests`
+	}()
+
+	// Bad: the transaction is passed to a goroutine
+	go trx.Commit(ctx, nil) // want `transaction trx is used from another goroutine`
+
+	group, groupCtx := errgroup.WithContext(ctx)
+	for _, id := range ids {
+		// Bad: collections obtained from the transaction share it
+		group.Go(func() error {
+			var user map[string]any
+			_, err := col.ReadDocument(groupCtx, id, &user) // want `collection col of transaction trx is used from another goroutine`
+
+			return err
+		})
+	}
+
+	return group.Wait()
+}
+
+func sharedCursorGoroutines(ctx context.Context, db arangodb.Database, cursors chan arangodb.Cursor) error {
+	cursor, err := db.Query(ctx, "FOR u IN users RETURN u", nil)
+	if err != nil {
+		return err
+	}
+	defer cursor.Close()
+
+	// Bad: cursors are not safe for concurrent reads either
+	go processCursor(cursor) // want `cursor cursor is used from another goroutine`
+
+	// Bad: sending the cursor hands it to another goroutine
+	cursors <- cursor // want `cursor cursor is sent over a channel`
+
+	return nil
+}
+
+func ownTransactionPerGoroutine(ctx context.Context, db arangodb.Database, ids []string) error {
+	group, groupCtx := errgroup.WithContext(ctx)
+	for _, id := range ids {
+		// Good: each goroutine begins and closes its own transaction
+		group.Go(func() error {
+			trx, err := db.BeginTransaction(groupCtx, arangodb.TransactionCollections{}, &arangodb.BeginTransactionOptions{AllowImplicit: true})
+			if err != nil {
+				return err
+			}
+
+			col, err := trx.GetCollection(groupCtx, "users", nil)
+			if err != nil {
+				trx.Abort(groupCtx, nil)
+
+				return err
+			}
+
+			var user map[string]any
+			if _, err := col.ReadDocument(groupCtx, id, &user); err != nil {
+				trx.Abort(groupCtx, nil)
+
+				return err
+			}
+
+			return trx.Commit(groupCtx, nil)
+		})
+	}
+
+	return group.Wait()
+}
+
+func sharedDatabaseGoroutines(ctx context.Context, db arangodb.Database) {
+	col, err := db.GetCollection(ctx, "users", nil)
+	if err != nil {
+		return
+	}
+
+	// Good: databases and collections outside transactions are safe to share
+	go func() {
+		var user map[string]any
+		consumeReader(db.Query(ctx, "FOR u IN users RETURN u", nil))
+		col.ReadDocument(ctx, "key", &user)
+	}()
+}
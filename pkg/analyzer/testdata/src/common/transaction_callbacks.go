@@ -0,0 +1,109 @@
+package common
+
+import (
+	"context"
+	"log"
+
+	"github.com/arangodb/go-driver/v2/arangodb"
+	"github.com/arangodb/go-driver/v2/arangodb/shared"
+)
+
+type callbackDoc struct {
+	Name string `json:"name"`
+}
+
+func transactionCallbacks(ctx context.Context, db arangodb.Database) error {
+	cols := arangodb.TransactionCollections{Write: []string{"users"}}
+	opts := &arangodb.BeginTransactionOptions{AllowImplicit: false}
+
+	// Bad
+	_ = db.WithTransaction(ctx, cols, opts, nil, nil, func(ctx context.Context, tx arangodb.Transaction) error {
+		coll, err := tx.GetCollection(ctx, "users", nil)
+		if err != nil {
+			log.Print(err)
+			return nil // want "WithTransaction callback returns nil after GetCollection failed; the transaction would be committed"
+		}
+
+		coll.CreateDocument(ctx, callbackDoc{})               // want "error from CreateDocument is dropped in a WithTransaction callback; the transaction would be committed"
+		_, _ = coll.UpdateDocument(ctx, "key", callbackDoc{}) // want "error from UpdateDocument is dropped in a WithTransaction callback; the transaction would be committed"
+
+		if _, err := coll.DeleteDocument(ctx, "old"); err != nil {
+			return nil // want "WithTransaction callback returns nil after DeleteDocument failed; the transaction would be committed"
+		}
+
+		if err := tx.Commit(ctx, nil); err != nil { // want "Commit called on the transaction of a WithTransaction callback; return an error or nil instead"
+			return err
+		}
+
+		tx.Abort(ctx, nil) // want "Abort called on the transaction of a WithTransaction callback; return an error or nil instead"
+
+		return nil
+	})
+
+	// Bad: err is reused by a later call
+	_ = db.WithTransaction(ctx, cols, opts, nil, nil, func(ctx context.Context, tx arangodb.Transaction) error {
+		coll, err := tx.GetCollection(ctx, "users", nil)
+		if err != nil {
+			return nil // want "WithTransaction callback returns nil after GetCollection failed; the transaction would be committed"
+		}
+
+		_, err = coll.CreateDocument(ctx, callbackDoc{})
+		if err != nil {
+			return err
+		}
+
+		return nil
+	})
+
+	// Bad: the error is logged, then execution falls through to return nil
+	_ = db.WithTransaction(ctx, cols, opts, nil, nil, func(ctx context.Context, tx arangodb.Transaction) error {
+		coll, err := tx.GetCollection(ctx, "users", nil)
+		if err != nil {
+			return err
+		}
+
+		if _, err := coll.ReplaceDocument(ctx, "key", callbackDoc{}); err != nil {
+			log.Print(err)
+		}
+
+		return nil // want "WithTransaction callback returns nil after ReplaceDocument failed; the transaction would be committed"
+	})
+
+	// Good: a missing document is deliberately ignored
+	_ = db.WithTransaction(ctx, cols, opts, nil, nil, func(ctx context.Context, tx arangodb.Transaction) error {
+		coll, err := tx.GetCollection(ctx, "users", nil)
+		if err != nil {
+			return err
+		}
+
+		if _, err := coll.DeleteDocument(ctx, "old"); err != nil {
+			if shared.IsNotFound(err) {
+				return nil
+			}
+
+			return err
+		}
+
+		return nil
+	})
+
+	// Good
+	return db.WithTransaction(ctx, cols, opts, nil, nil, func(ctx context.Context, tx arangodb.Transaction) error {
+		coll, err := tx.GetCollection(ctx, "users", nil)
+		if err != nil {
+			return err
+		}
+
+		if _, err := coll.CreateDocument(ctx, callbackDoc{}); err != nil {
+			return err
+		}
+
+		cursor, err := tx.Query(ctx, "FOR u IN users RETURN u", nil)
+		if err != nil {
+			return err
+		}
+		defer cursor.Close()
+
+		return nil
+	})
+}
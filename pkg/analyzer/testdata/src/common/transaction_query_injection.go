@@ -108,7 +108,7 @@ func transactionQueryInjectionWithControlFlow(db arangodb.Database, userName str
 	filters := []string{userName, "john", "jane"}
 	for _, name := range filters {
 		loopQuery := "FOR u IN users FILTER u.name == '" + name + "' RETURN u"
-		trx.Query(ctx, loopQuery, nil) // want "query string uses concatenation instead of bind variables"
+		trx.Query(ctx, loopQuery, nil) // want "query string uses concatenation instead of bind variables" "Query called in a loop with per-iteration arguments; use an AQL FOR over a bind array"
 	}
 
 	// SAFE: Using bind vars with control flow
@@ -130,7 +130,7 @@ func transactionQueryInjectionWithControlFlow(db arangodb.Database, userName str
 
 	// SAFE: Building query in loop with bind vars
 	for _, name := range filters {
-		trx.Query(ctx, "FOR u IN users FILTER u.name == @name RETURN u", &arangodb.QueryOptions{
+		trx.Query(ctx, "FOR u IN users FILTER u.name == @name RETURN u", &arangodb.QueryOptions{ // want "Query called in a loop with per-iteration arguments; use an AQL FOR over a bind array"
 			BindVars: map[string]interface{}{
 				"name": name,
 			},
@@ -0,0 +1,49 @@
+package common
+
+import (
+	"context"
+
+	"github.com/arangodb/go-driver/v2/arangodb"
+)
+
+const purgeQuery = `
+	FOR s IN sessions
+		REMOVE s IN sessions`
+
+// The filter applies to users: every session is removed once per inactive user.
+const inactiveSessionsQuery = `
+	FOR u IN users
+		FILTER u.inactive == true
+		FOR s IN sessions
+			REMOVE s IN sessions`
+
+func unboundedWrites(ctx context.Context, db arangodb.Database, trx arangodb.Transaction) {
+	// Bad: modifications over a whole collection
+	db.Query(ctx, "FOR d IN sessions REMOVE d IN sessions", nil)                                     // want `REMOVE iterates the whole collection "sessions" without FILTER, LIMIT or SEARCH; annotate reviewed queries with //arangolint:allow-unbounded-write <reason>`
+	db.Query(ctx, "FOR u IN users UPDATE u WITH {active: false} IN users", nil)                      // want `UPDATE iterates the whole collection "users" without FILTER, LIMIT or SEARCH`
+	trx.Query(ctx, "FOR u IN users REPLACE u WITH {name: u.name} IN users", nil)                     // want `REPLACE iterates the whole collection "users" without FILTER, LIMIT or SEARCH`
+	db.Query(ctx, "FOR u IN users UPSERT {_key: u._key} INSERT u UPDATE {seen: true} IN users", nil) // want `UPSERT iterates the whole collection "users" without FILTER, LIMIT or SEARCH`
+	db.Query(ctx, "FOR d IN sessions REMOVE d IN @@target", nil)                                     // want `REMOVE iterates the whole collection "sessions" without FILTER, LIMIT or SEARCH`
+	db.QueryBatch(ctx, purgeQuery, nil, nil)                                                         // want `REMOVE iterates the whole collection "sessions" without FILTER, LIMIT or SEARCH`
+	db.Query(ctx, inactiveSessionsQuery, nil)                                                        // want `REMOVE iterates the whole collection "sessions" without FILTER, LIMIT or SEARCH`
+
+	// Bad: the annotation needs a reason
+	//arangolint:allow-unbounded-write
+	db.Query(ctx, "FOR d IN sessions REMOVE d IN sessions", nil) // want `REMOVE iterates the whole collection "sessions" without FILTER, LIMIT or SEARCH`
+
+	// Good: reviewed exception
+	//arangolint:allow-unbounded-write sessions are rebuilt on login after each deploy
+	db.Query(ctx, "FOR d IN sessions REMOVE d IN sessions", nil)
+
+	// Good: filtered, limited or key lookups
+	db.Query(ctx, "FOR d IN sessions FILTER d.expiresAt < DATE_NOW() REMOVE d IN sessions", nil)
+	db.Query(ctx, "FOR d IN sessions SORT d.createdAt LIMIT 1000 REMOVE d IN sessions", nil)
+	db.Query(ctx, "FOR key IN @keys REMOVE key IN sessions", nil)
+	db.Query(ctx, "REMOVE @key IN sessions", nil)
+	db.Query(ctx, "FOR u IN users INSERT u INTO archive", nil)
+
+	// Good: the modified collection is not the iterated one
+	db.Query(ctx, "FOR u IN users UPSERT {_key: u._key} INSERT u UPDATE {} IN archive", nil)
+	db.Query(ctx, "FOR u IN users REMOVE {_key: u._key} IN staleUsers OPTIONS {ignoreErrors: true}", nil)
+	db.ValidateQuery(ctx, "FOR d IN sessions REMOVE d IN sessions")
+}
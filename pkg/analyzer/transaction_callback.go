@@ -0,0 +1,315 @@
+package analyzer
+
+import (
+	"go/ast"
+	"go/token"
+	"go/types"
+	"slices"
+
+	"golang.org/x/tools/go/analysis"
+	"golang.org/x/tools/go/cfg"
+)
+
+const (
+	msgExplicitCloseInCallback = "%s called on the transaction of a WithTransaction callback; return an error or nil instead"
+	msgDroppedErrorInCallback  = "error from %s is dropped in a WithTransaction callback; the transaction would be committed"
+	msgNilAfterErrorInCallback = "WithTransaction callback returns nil after %s failed; the transaction would be committed"
+	methodClose                = "Close"
+	methodCloseWithContext     = "CloseWithContext"
+)
+
+// handleTransactionCallbackCall validates the TransactionWrap function literal
+// passed to Database.WithTransaction. WithTransaction commits when the callback
+// returns nil and aborts otherwise, so the callback must neither Commit/Abort
+// the transaction itself nor swallow driver errors and return nil, which
+// commits partial work.
+func handleTransactionCallbackCall(call *ast.CallExpr, pass *analysis.Pass) {
+	callback := withTransactionCallback(call, pass)
+	if callback == nil {
+		return
+	}
+
+	var trxObj types.Object
+	if trxIdent := callbackTransactionParam(callback); trxIdent != nil {
+		trxObj = pass.TypesInfo.Defs[trxIdent]
+	}
+
+	inspectCallback(callback.Body, func(node ast.Node) {
+		switch typed := node.(type) {
+		case *ast.CallExpr:
+			reportExplicitClose(typed, trxObj, pass)
+		case *ast.ExprStmt:
+			if inner, isCall := unwrapParens(typed.X).(*ast.CallExpr); isCall {
+				if name := errorReturningDriverCall(inner, pass); name != "" {
+					pass.Reportf(inner.Pos(), msgDroppedErrorInCallback, name)
+				}
+			}
+		case *ast.AssignStmt:
+			reportBlankDriverError(typed, pass)
+		}
+	})
+
+	if graph := funcCFG(callback, pass); graph != nil {
+		reportSwallowedErrors(graph, pass)
+	}
+}
+
+// inspectCallback visits the nodes of body, skipping nested function literals.
+func inspectCallback(body *ast.BlockStmt, visit func(ast.Node)) {
+	ast.Inspect(body, func(node ast.Node) bool {
+		if _, isFuncLit := node.(*ast.FuncLit); isFuncLit {
+			return false
+		}
+
+		if node != nil {
+			visit(node)
+		}
+
+		return true
+	})
+}
+
+// reportExplicitClose reports trx.Commit(...) and trx.Abort(...) on the
+// callback's transaction.
+func reportExplicitClose(call *ast.CallExpr, trxObj types.Object, pass *analysis.Pass) {
+	if trxObj != nil && isTransactionClose(call, trxObj, pass) {
+		pass.Reportf(call.Pos(), msgExplicitCloseInCallback, call.Fun.(*ast.SelectorExpr).Sel.Name) //nolint:forcetypeassert // checked by isTransactionClose
+	}
+}
+
+// reportBlankDriverError reports driver calls whose error is assigned to the
+// blank identifier.
+func reportBlankDriverError(assign *ast.AssignStmt, pass *analysis.Pass) {
+	if len(assign.Rhs) != 1 || len(assign.Lhs) == 0 {
+		return
+	}
+
+	call, isCall := unwrapParens(assign.Rhs[0]).(*ast.CallExpr)
+	if !isCall {
+		return
+	}
+
+	if errIdent, isIdent := assign.Lhs[len(assign.Lhs)-1].(*ast.Ident); isIdent && errIdent.Name == "_" {
+		if name := errorReturningDriverCall(call, pass); name != "" {
+			pass.Reportf(call.Pos(), msgDroppedErrorInCallback, name)
+		}
+	}
+}
+
+// reportSwallowedErrors reports the "return nil" statements of the callback
+// reachable from a branch taken when a driver call failed (err != nil), unless
+// the error is inspected again on the way. The driver call is the assignment of
+// err reaching the check.
+func reportSwallowedErrors(graph *cfg.CFG, pass *analysis.Pass) {
+	preds := make(map[*cfg.Block][]*cfg.Block)
+
+	for _, block := range graph.Blocks {
+		for _, succ := range block.Succs {
+			preds[succ] = append(preds[succ], block)
+		}
+	}
+
+	reported := make(map[*ast.ReturnStmt][]string)
+
+	for _, block := range graph.Blocks {
+		if len(block.Succs) != 2 || len(block.Nodes) == 0 { //nolint:mnd // conditional blocks have two successors
+			continue
+		}
+
+		cond := block.Nodes[len(block.Nodes)-1]
+
+		errObj := nilComparedObject(cond, pass)
+		if errObj == nil {
+			continue
+		}
+
+		failed := block.Succs[0]
+		if errNilComparison(cond, errObj, pass) == token.EQL {
+			failed = block.Succs[1]
+		}
+
+		names := reachingDriverCalls(block, len(block.Nodes)-1, errObj, preds, pass)
+		if len(names) == 0 {
+			continue
+		}
+
+		for _, ret := range nilReturnsAfter(failed, errObj, pass) {
+			for _, name := range names {
+				if !slices.Contains(reported[ret], name) {
+					reported[ret] = append(reported[ret], name)
+					pass.Reportf(ret.Pos(), msgNilAfterErrorInCallback, name)
+				}
+			}
+		}
+	}
+}
+
+// nilComparedObject returns the variable node compares with nil, or nil.
+func nilComparedObject(node ast.Node, pass *analysis.Pass) types.Object {
+	binExpr, isBinary := node.(*ast.BinaryExpr)
+	if !isBinary {
+		return nil
+	}
+
+	for _, operand := range []ast.Expr{binExpr.X, binExpr.Y} {
+		if id, isIdent := unwrapParens(operand).(*ast.Ident); isIdent && !isNilIdent(id) {
+			if obj, isVar := pass.TypesInfo.ObjectOf(id).(*types.Var); isVar &&
+				errNilComparison(node, obj, pass) != token.ILLEGAL {
+				return obj
+			}
+		}
+	}
+
+	return nil
+}
+
+// reachingDriverCalls returns the names of the driver calls whose error is the
+// value of errObj before the node at index end of block, following the
+// control flow backwards to the closest assignments of errObj.
+func reachingDriverCalls(
+	block *cfg.Block,
+	end int,
+	errObj types.Object,
+	preds map[*cfg.Block][]*cfg.Block,
+	pass *analysis.Pass,
+) []string {
+	var names []string
+
+	visited := make(map[*cfg.Block]bool)
+
+	var walk func(block *cfg.Block, end int)
+
+	walk = func(block *cfg.Block, end int) {
+		for index := end - 1; index >= 0; index-- {
+			if name, assigns := errAssignment(block.Nodes[index], errObj, pass); assigns {
+				if name != "" && !slices.Contains(names, name) {
+					names = append(names, name)
+				}
+
+				return
+			}
+		}
+
+		for _, pred := range preds[block] {
+			if !visited[pred] {
+				visited[pred] = true
+				walk(pred, len(pred.Nodes))
+			}
+		}
+	}
+
+	walk(block, end)
+
+	return names
+}
+
+// errAssignment reports whether node assigns errObj, along with the name of
+// the driver method whose error it receives, if any.
+func errAssignment(node ast.Node, errObj types.Object, pass *analysis.Pass) (string, bool) {
+	var (
+		lhs    []ast.Expr
+		values []ast.Expr
+	)
+
+	switch typed := node.(type) {
+	case *ast.AssignStmt:
+		lhs, values = typed.Lhs, typed.Rhs
+	case *ast.ValueSpec:
+		for _, name := range typed.Names {
+			lhs = append(lhs, name)
+		}
+
+		values = typed.Values
+	default:
+		return "", false
+	}
+
+	position := slices.IndexFunc(lhs, func(expr ast.Expr) bool {
+		id, isIdent := expr.(*ast.Ident)
+
+		return isIdent && pass.TypesInfo.ObjectOf(id) == errObj
+	})
+	if position < 0 {
+		return "", false
+	}
+
+	if len(values) != 1 || position != len(lhs)-1 {
+		return "", true
+	}
+
+	call, isCall := unwrapParens(values[0]).(*ast.CallExpr)
+	if !isCall {
+		return "", true
+	}
+
+	return errorReturningDriverCall(call, pass), true
+}
+
+// nilReturnsAfter returns the "return nil" statements reachable from block.
+// Paths stop at other return statements and at branches whose condition
+// inspects errObj again, such as shared.IsNotFound(err).
+func nilReturnsAfter(block *cfg.Block, errObj types.Object, pass *analysis.Pass) []*ast.ReturnStmt {
+	var returns []*ast.ReturnStmt
+
+	visited := map[*cfg.Block]bool{block: true}
+
+	var walk func(block *cfg.Block)
+
+	walk = func(block *cfg.Block) {
+		for _, node := range block.Nodes {
+			if ret, isReturn := node.(*ast.ReturnStmt); isReturn {
+				if len(ret.Results) == 1 && isNilIdent(ret.Results[0]) {
+					returns = append(returns, ret)
+				}
+
+				return
+			}
+		}
+
+		if len(block.Succs) > 1 && len(block.Nodes) > 0 {
+			cond, isExpr := block.Nodes[len(block.Nodes)-1].(ast.Expr)
+			if isExpr && referencesAny([]ast.Expr{cond}, map[types.Object]bool{errObj: true}, pass) {
+				return
+			}
+		}
+
+		for _, succ := range block.Succs {
+			if !visited[succ] {
+				visited[succ] = true
+				walk(succ)
+			}
+		}
+	}
+
+	walk(block)
+
+	return returns
+}
+
+// errorReturningDriverCall returns the name of the arangodb method invoked by
+// call when its last result is an error, or an empty string. Closing cursors
+// does not affect the transaction outcome, and committing or aborting it is
+// reported separately, so those are not considered.
+func errorReturningDriverCall(call *ast.CallExpr, pass *analysis.Pass) string {
+	method := arangoMethod(call, pass)
+	if method == nil {
+		return ""
+	}
+
+	switch method.Name() {
+	case methodClose, methodCloseWithContext, methodCommit, methodAbort:
+		return ""
+	}
+
+	sig, isSignature := method.Type().(*types.Signature)
+	if !isSignature || sig.Results().Len() == 0 {
+		return ""
+	}
+
+	last := sig.Results().At(sig.Results().Len() - 1).Type()
+	if !types.Identical(last, types.Universe.Lookup("error").Type()) {
+		return ""
+	}
+
+	return method.Name()
+}
@@ -0,0 +1,175 @@
+package analyzer
+
+import (
+	"go/ast"
+	"go/token"
+	"go/types"
+
+	"golang.org/x/tools/go/analysis"
+)
+
+const (
+	methodWithTransaction = "WithTransaction"
+	methodCommit          = "Commit"
+	methodAbort           = "Abort"
+)
+
+// openTransaction describes the span of a function body during which a
+// streaming transaction is open.
+type openTransaction struct {
+	name  string
+	obj   types.Object
+	start token.Pos
+	end   token.Pos
+	body  *ast.BlockStmt
+	// cols is the TransactionCollections argument of the starting call.
+	cols ast.Expr
+	// closed is false when a BeginTransaction has no Commit/Abort in the
+	// function, in which case end is the end of the function.
+	closed bool
+}
+
+// beginTransactionSpan returns the span during which the transaction started by
+// call (a BeginTransaction call bound to a variable) is open: from the binding
+// statement to the last non-deferred Commit or Abort on it, or to the end of
+// the function when it is never closed explicitly.
+func beginTransactionSpan(call *ast.CallExpr, pass *analysis.Pass, stack []ast.Node) *openTransaction {
+	if !isBeginTransaction(call, pass) || len(stack) < 2 {
+		return nil
+	}
+
+	bindNode := stack[len(stack)-2]
+
+	trxIdent, _, _ := resultBinding(call, bindNode)
+	if trxIdent == nil {
+		return nil
+	}
+
+	trxObj := pass.TypesInfo.ObjectOf(trxIdent)
+	body := funcBody(enclosingFunc(stack))
+
+	if trxObj == nil || body == nil {
+		return nil
+	}
+
+	span := &openTransaction{
+		name:  trxIdent.Name,
+		obj:   trxObj,
+		start: bindNode.End(),
+		end:   body.End(),
+		body:  body,
+		cols:  call.Args[1],
+	}
+
+	if closePos := lastTransactionClose(body, trxObj, bindNode.End(), pass); closePos.IsValid() {
+		span.end = closePos
+		span.closed = true
+	}
+
+	return span
+}
+
+// withTransactionCallback returns the TransactionWrap function literal passed
+// to a Database.WithTransaction call, or nil.
+func withTransactionCallback(call *ast.CallExpr, pass *analysis.Pass) *ast.FuncLit {
+	method := arangoMethod(call, pass)
+	if method == nil || method.Name() != methodWithTransaction || len(call.Args) == 0 {
+		return nil
+	}
+
+	callback, isFuncLit := unwrapParens(call.Args[len(call.Args)-1]).(*ast.FuncLit)
+	if !isFuncLit {
+		return nil
+	}
+
+	return callback
+}
+
+// withTransactionSpan returns the span of a WithTransaction callback body,
+// during which the transaction passed to the callback is open.
+func withTransactionSpan(call *ast.CallExpr, pass *analysis.Pass) *openTransaction {
+	callback := withTransactionCallback(call, pass)
+	if callback == nil {
+		return nil
+	}
+
+	span := &openTransaction{
+		name:   methodWithTransaction,
+		start:  callback.Body.Pos(),
+		end:    callback.Body.End(),
+		body:   callback.Body,
+		cols:   call.Args[1],
+		closed: true,
+	}
+
+	if trxIdent := callbackTransactionParam(callback); trxIdent != nil {
+		span.name = trxIdent.Name
+		span.obj = pass.TypesInfo.Defs[trxIdent]
+	}
+
+	return span
+}
+
+// callbackTransactionParam returns the named Transaction parameter of a
+// TransactionWrap literal (its second parameter), or nil.
+func callbackTransactionParam(callback *ast.FuncLit) *ast.Ident {
+	var names []*ast.Ident
+
+	for _, field := range callback.Type.Params.List {
+		names = append(names, field.Names...)
+	}
+
+	if len(names) != 2 || names[1].Name == "_" { //nolint:mnd // ctx and transaction
+		return nil
+	}
+
+	return names[1]
+}
+
+// lastTransactionClose returns the position of the last non-deferred Commit or
+// Abort call on trxObj after start in body, or token.NoPos.
+func lastTransactionClose(body *ast.BlockStmt, trxObj types.Object, start token.Pos, pass *analysis.Pass) token.Pos {
+	closePos := token.NoPos
+
+	ast.Inspect(body, func(node ast.Node) bool {
+		switch typed := node.(type) {
+		case *ast.DeferStmt, *ast.FuncLit:
+			return false
+		case *ast.CallExpr:
+			if typed.Pos() < start {
+				return true
+			}
+
+			if isTransactionClose(typed, trxObj, pass) {
+				closePos = typed.Pos()
+			}
+		}
+
+		return true
+	})
+
+	return closePos
+}
+
+// isTransactionClose reports whether call is trx.Commit(...) or trx.Abort(...)
+// on the transaction trxObj.
+func isTransactionClose(call *ast.CallExpr, trxObj types.Object, pass *analysis.Pass) bool {
+	for _, methodName := range []string{methodCommit, methodAbort} {
+		if receiver := methodCallReceiver(call, methodName); receiver != nil &&
+			pass.TypesInfo.ObjectOf(receiver) == trxObj {
+			return true
+		}
+	}
+
+	return false
+}
+
+// contains reports whether pos lies within the open span.
+func (t *openTransaction) contains(pos token.Pos) bool {
+	return pos >= t.start && pos < t.end
+}
+
+// overlaps reports whether node intersects the open span.
+func (t *openTransaction) overlaps(node ast.Node) bool {
+	return node.End() > t.start && node.Pos() < t.end
+}
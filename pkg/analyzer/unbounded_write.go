@@ -0,0 +1,102 @@
+package analyzer
+
+import (
+	"go/ast"
+	"slices"
+
+	"golang.org/x/tools/go/analysis"
+)
+
+const (
+	msgUnboundedWrite            = "%s iterates the whole collection %q without FILTER, LIMIT or SEARCH; annotate reviewed queries with //%s <reason>"
+	directiveAllowUnboundedWrite = "arangolint:allow-unbounded-write"
+)
+
+// aqlModificationKeywords lists the operations changing or removing existing
+// documents.
+var aqlModificationKeywords = []string{aqlKeywordRemove, aqlKeywordUpdate, aqlKeywordReplace, aqlKeywordUpsert}
+
+// aqlBoundingKeywords lists the operations restricting the documents a loop
+// visits.
+var aqlBoundingKeywords = []string{aqlKeywordFilter, aqlKeywordLimit, aqlKeywordSearch}
+
+// handleUnboundedWriteCall reports constant queries executed with Query or
+// QueryBatch that remove, update, replace or upsert documents of the whole
+// collection they iterate, unless annotated with
+// //arangolint:allow-unbounded-write and a reason.
+func handleUnboundedWriteCall(call *ast.CallExpr, pass *analysis.Pass) {
+	methodName, queryArgIndex := identifyQueryMethod(call, pass)
+	if (methodName != methodQuery && methodName != methodQueryBatch) || len(call.Args) <= queryArgIndex {
+		return
+	}
+
+	query, known := constantString(call.Args[queryArgIndex], pass)
+	if !known {
+		return
+	}
+
+	for _, loop := range parseAQL(query).loops {
+		keyword := unboundedModification(loop)
+		if keyword == "" {
+			continue
+		}
+
+		if reason, found := directiveReason(call, directiveAllowUnboundedWrite, pass); found && reason != "" {
+			return
+		}
+
+		pass.Reportf(call.Args[queryArgIndex].Pos(), msgUnboundedWrite, keyword, loop.collection, directiveAllowUnboundedWrite)
+	}
+}
+
+// unboundedModification returns the keyword of the first modification of the
+// iterated collection in the body of a loop iterating a collection without
+// FILTER, LIMIT or SEARCH, or an empty string. Modifications of another
+// collection, such as copies into an archive, are not considered.
+func unboundedModification(loop *aqlLoop) string {
+	if loop.collection == "" {
+		return ""
+	}
+
+	modification := ""
+
+	for index, clause := range loop.body {
+		switch {
+		case slices.Contains(aqlBoundingKeywords, clause.keyword):
+			return ""
+		case modification == "" && slices.Contains(aqlModificationKeywords, clause.keyword):
+			if target := modificationTarget(loop.body[index:]); target == "" || target == loop.collection {
+				modification = clause.keyword
+			}
+		}
+	}
+
+	return modification
+}
+
+// modificationTarget returns the collection named after the top-level IN or
+// INTO of the first of clauses, or of the clauses completing it (the INSERT,
+// UPDATE or REPLACE parts of an UPSERT). It returns an empty string when the
+// collection is not a plain name, such as a bind parameter.
+func modificationTarget(clauses []*aqlClause) string {
+	for _, clause := range clauses {
+		depth := 0
+
+		for index, tok := range clause.tokens {
+			switch {
+			case tok.isPunct("(", "[", "{"):
+				depth++
+			case tok.isPunct(")", "]", "}"):
+				depth--
+			case depth == 0 && (tok.isKeyword(aqlKeywordIn) || tok.isKeyword(aqlKeywordInto)):
+				if index+1 < len(clause.tokens) && clause.tokens[index+1].kind == aqlName {
+					return clause.tokens[index+1].text
+				}
+
+				return ""
+			}
+		}
+	}
+
+	return ""
+}